@@ -0,0 +1,75 @@
+// Команда dupscan ищет вероятные дубликаты песен и сохраняет их для ручной проверки.
+//
+// Запуск из корня репозитория:
+//
+//	go run ./cmd/dupscan -min-similarity 0.8
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"song-libary/config"
+	"song-libary/db"
+	"song-libary/logging"
+	"song-libary/repository"
+	"song-libary/service"
+	"syscall"
+)
+
+func main() {
+	minSimilarity := flag.Float64("min-similarity", service.DefaultMinLyricSimilarity, "Minimal lyric similarity (0-1) for songs with matching names")
+	cfg, err := config.LoadWithFlags(flag.CommandLine, os.Args[1:])
+	if errors.Is(err, config.ErrPrintConfig) {
+		fmt.Print(cfg)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	logger, err := logging.New(cfg.Log, os.Stderr)
+	if err != nil {
+		slog.Error("Failed to create logger", "error", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(logger)
+
+	// Прерывание по Ctrl+C отменяет текущие запросы к базе данных
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbManager := db.NewDbManager(logger)
+	if err := dbManager.InitDB(ctx, cfg.Database); err != nil {
+		logger.Error("Failed to initialize database", "error", err)
+		os.Exit(1)
+	}
+	defer dbManager.Close()
+
+	// Миграции гарантируют наличие таблицы duplicate_candidates
+	if err := dbManager.ApplyMigrations(ctx, cfg.Database.MigrationsDir); err != nil {
+		logger.Error("Failed to apply migrations", "error", err)
+		os.Exit(1)
+	}
+
+	duplicateService := service.NewDuplicateService(
+		repository.NewDuplicateRepositorySqlDbImpl(dbManager.DB, logger),
+		repository.NewSongRepositorySqlDbImpl(dbManager.DB, logger),
+		service.NewSimilarityIndex(),
+		logger,
+	)
+	duplicateService.MinSimilarity = *minSimilarity
+
+	created, err := duplicateService.ScanDuplicates(ctx)
+	if err != nil {
+		logger.Error("Duplicate scan failed", "error", err)
+		os.Exit(1)
+	}
+
+	logger.Info("Duplicate scan completed", "created", created)
+}
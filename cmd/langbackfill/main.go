@@ -0,0 +1,68 @@
+// Команда langbackfill определяет язык текста для песен, у которых он ещё не сохранён.
+//
+// Запуск из корня репозитория:
+//
+//	go run ./cmd/langbackfill -batch 500
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"song-libary/config"
+	"song-libary/db"
+	"song-libary/logging"
+	"song-libary/repository"
+	"song-libary/service"
+	"syscall"
+)
+
+func main() {
+	batchSize := flag.Int("batch", 200, "Number of songs processed per batch")
+	cfg, err := config.LoadWithFlags(flag.CommandLine, os.Args[1:])
+	if errors.Is(err, config.ErrPrintConfig) {
+		fmt.Print(cfg)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	logger, err := logging.New(cfg.Log, os.Stderr)
+	if err != nil {
+		slog.Error("Failed to create logger", "error", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(logger)
+
+	// Прерывание по Ctrl+C отменяет текущие запросы к базе данных
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbManager := db.NewDbManager(logger)
+	if err := dbManager.InitDB(ctx, cfg.Database); err != nil {
+		logger.Error("Failed to initialize database", "error", err)
+		os.Exit(1)
+	}
+	defer dbManager.Close()
+
+	// Миграции гарантируют наличие столбцов language и language_confidence
+	if err := dbManager.ApplyMigrations(ctx, cfg.Database.MigrationsDir); err != nil {
+		logger.Error("Failed to apply migrations", "error", err)
+		os.Exit(1)
+	}
+
+	songService := service.NewSongService(repository.NewSongRepositorySqlDbImpl(dbManager.DB, logger), logger)
+	updated, err := songService.BackfillLanguages(ctx, *batchSize)
+	if err != nil {
+		logger.Error("Language backfill failed", "updated", updated, "error", err)
+		os.Exit(1)
+	}
+
+	logger.Info("Language backfill completed", "updated", updated)
+}
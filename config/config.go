@@ -0,0 +1,383 @@
+// Package config описывает настройки сервиса. Значения загружаются по порядку из значений по умолчанию,
+// необязательного файла YAML или TOML, переменных окружения и флагов командной строки;
+// каждый следующий источник переопределяет предыдущий.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// redacted заменяет секреты при выводе конфигурации
+const redacted = "[REDACTED]"
+
+// Config содержит все настройки сервиса
+type Config struct {
+	Server      ServerConfig      `yaml:"server" toml:"server"`
+	Database    DatabaseConfig    `yaml:"database" toml:"database"`
+	Timeouts    TimeoutsConfig    `yaml:"timeouts" toml:"timeouts"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit" toml:"rate_limit"`
+	Log         LogConfig         `yaml:"log" toml:"log"`
+	Tracing     TracingConfig     `yaml:"tracing" toml:"tracing"`
+	Features    FeaturesConfig    `yaml:"features" toml:"features"`
+	LinkChecker LinkCheckerConfig `yaml:"link_checker" toml:"link_checker"`
+	Profanity   ProfanityConfig   `yaml:"profanity" toml:"profanity"`
+}
+
+// ServerConfig содержит настройки HTTP-сервера
+type ServerConfig struct {
+	Addr              string        `yaml:"addr" toml:"addr"`                               // Адрес, например :8080
+	ReadTimeout       time.Duration `yaml:"read_timeout" toml:"read_timeout"`               // Время на чтение запроса целиком
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" toml:"read_header_timeout"` // Время на чтение заголовков
+	WriteTimeout      time.Duration `yaml:"write_timeout" toml:"write_timeout"`             // Время на запись ответа
+	IdleTimeout       time.Duration `yaml:"idle_timeout" toml:"idle_timeout"`               // Время жизни простаивающего keep-alive соединения
+	ShutdownDelay     time.Duration `yaml:"shutdown_delay" toml:"shutdown_delay"`           // Пауза между снятием готовности и остановкой приёма соединений
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`       // Время на завершение запросов при остановке
+	TLS               TLSConfig     `yaml:"tls" toml:"tls"`
+}
+
+// TLSConfig содержит пути к сертификату и ключу; TLS включается, если заданы оба
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file" toml:"cert_file"`
+	KeyFile  string `yaml:"key_file" toml:"key_file"`
+}
+
+// Enabled сообщает, что сервер должен принимать соединения по TLS
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// DatabaseConfig содержит параметры подключения к PostgreSQL и пула соединений
+type DatabaseConfig struct {
+	Host            string        `yaml:"host" toml:"host"`
+	Port            int           `yaml:"port" toml:"port"`
+	User            string        `yaml:"user" toml:"user"`
+	Password        string        `yaml:"password" toml:"password"`
+	Name            string        `yaml:"name" toml:"name"`
+	SSLMode         string        `yaml:"sslmode" toml:"sslmode"`
+	MaxOpenConns    int           `yaml:"max_open_conns" toml:"max_open_conns"`         // 0 — без ограничения
+	MaxIdleConns    int           `yaml:"max_idle_conns" toml:"max_idle_conns"`         // Простаивающие соединения в пуле
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime"`   // 0 — без ограничения
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" toml:"conn_max_idle_time"` // 0 — без ограничения
+	MigrationsDir   string        `yaml:"migrations_dir" toml:"migrations_dir"`
+}
+
+// TimeoutsConfig задаёт дедлайны обработки запросов. По истечении дедлайна запросы к базе данных
+// отменяются, а клиент получает 504
+type TimeoutsConfig struct {
+	Default    time.Duration            `yaml:"default" toml:"default"`       // Для маршрутов без собственного значения; 0 — без дедлайна
+	Operations map[string]time.Duration `yaml:"operations" toml:"operations"` // По шаблону пути маршрута без метода, например "/songs/merge": 20s
+}
+
+// For возвращает дедлайн для маршрута pattern
+func (c TimeoutsConfig) For(pattern string) time.Duration {
+	if timeout, ok := c.Operations[pattern]; ok {
+		return timeout
+	}
+	return c.Default
+}
+
+// RateLimitConfig задаёт ограничения частоты запросов. Клиент определяется по известному ключу API
+// из заголовка APIKeyHeader, а без него — по IP-адресу
+type RateLimitConfig struct {
+	Enabled           bool            `yaml:"enabled" toml:"enabled"`
+	Store             string          `yaml:"store" toml:"store"`                             // memory или postgres (общий для нескольких экземпляров)
+	Default           Rate            `yaml:"default" toml:"default"`                         // Для маршрутов без собственного значения
+	Routes            map[string]Rate `yaml:"routes" toml:"routes"`                           // По шаблону пути маршрута без метода, например "/songs": 60/m
+	APIKeyHeader      string          `yaml:"api_key_header" toml:"api_key_header"`           // Заголовок с ключом API
+	APIKeys           []string        `yaml:"api_keys" toml:"api_keys"`                       // Известные ключи; с другими ключами клиент считается по IP
+	TrustForwardedFor bool            `yaml:"trust_forwarded_for" toml:"trust_forwarded_for"` // Брать IP из X-Forwarded-For (только за прокси)
+	TrustedProxies    int             `yaml:"trusted_proxies" toml:"trusted_proxies"`         // Число доверенных прокси, дописывающих адрес в X-Forwarded-For
+}
+
+// For возвращает ограничение для маршрута pattern
+func (c RateLimitConfig) For(pattern string) Rate {
+	if rate, ok := c.Routes[pattern]; ok {
+		return rate
+	}
+	return c.Default
+}
+
+// Rate — не больше Requests запросов за Per; в текстовом виде "60/m" или "10/30s".
+// Нулевое значение снимает ограничение
+type Rate struct {
+	Requests int
+	Per      time.Duration
+}
+
+// UnmarshalText разбирает ограничение вида "60/m", "60/1m" или "10/30s"
+func (r *Rate) UnmarshalText(text []byte) error {
+	requests, period, ok := strings.Cut(strings.TrimSpace(string(text)), "/")
+	if !ok {
+		return fmt.Errorf("invalid rate %q, expected requests/period", text)
+	}
+	n, err := strconv.Atoi(requests)
+	if err != nil {
+		return fmt.Errorf("invalid rate %q: %w", text, err)
+	}
+	if period != "" && strings.IndexFunc(period[:1], unicode.IsDigit) < 0 {
+		period = "1" + period
+	}
+	per, err := time.ParseDuration(period)
+	if err != nil {
+		return fmt.Errorf("invalid rate %q: %w", text, err)
+	}
+	r.Requests, r.Per = n, per
+	return nil
+}
+
+// MarshalText записывает ограничение в том же виде, в каком оно задаётся
+func (r Rate) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r Rate) String() string {
+	// time.Duration.String пишет минуту как "1m0s", а час как "1h0m0s"
+	per := r.Per.String()
+	if strings.HasSuffix(per, "m0s") {
+		per = strings.TrimSuffix(per, "0s")
+	}
+	if strings.HasSuffix(per, "h0m") {
+		per = strings.TrimSuffix(per, "0m")
+	}
+	return fmt.Sprintf("%d/%s", r.Requests, per)
+}
+
+// LogConfig содержит настройки журнала
+type LogConfig struct {
+	Level  string `yaml:"level" toml:"level"`   // debug, info, warn или error
+	Format string `yaml:"format" toml:"format"` // text или json
+}
+
+// TracingConfig содержит настройки трассировки OpenTelemetry
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" toml:"exporter"`         // none, stdout или otlp
+	Endpoint    string  `yaml:"endpoint" toml:"endpoint"`         // URL приёмника OTLP/HTTP; пусто — OTEL_EXPORTER_OTLP_ENDPOINT или localhost:4318
+	ServiceName string  `yaml:"service_name" toml:"service_name"` // Имя сервиса в трассах
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio"` // Доля записываемых трасс от 0 до 1
+}
+
+// FeaturesConfig включает и отключает необязательные части сервиса
+type FeaturesConfig struct {
+	Swagger      bool `yaml:"swagger" toml:"swagger"`             // Swagger UI по адресу /swagger/
+	LinkChecker  bool `yaml:"link_checker" toml:"link_checker"`   // Фоновая проверка ссылок
+	SimilarSongs bool `yaml:"similar_songs" toml:"similar_songs"` // Индекс похожих песен и /songs/{id}/similar
+}
+
+// LinkCheckerConfig задаёт параметры фоновой проверки ссылок; сама проверка включается features.link_checker
+type LinkCheckerConfig struct {
+	Interval           time.Duration `yaml:"interval" toml:"interval"`                         // Пауза между проходами
+	RecheckAfter       time.Duration `yaml:"recheck_after" toml:"recheck_after"`               // Через сколько перепроверять уже проверенную ссылку
+	BatchSize          int           `yaml:"batch_size" toml:"batch_size"`                     // Сколько ссылок проверять за один проход
+	RequestsPerSecond  float64       `yaml:"requests_per_second" toml:"requests_per_second"`   // Общий лимит запросов в секунду; 0 — без ограничения
+	PerHostConcurrency int           `yaml:"per_host_concurrency" toml:"per_host_concurrency"` // Максимум одновременных запросов к одному хосту
+	Timeout            time.Duration `yaml:"timeout" toml:"timeout"`                           // Таймаут одного запроса
+	BrokenAfter        int           `yaml:"broken_after" toml:"broken_after"`                 // После скольких неудач подряд ссылка считается нерабочей
+	UserAgent          string        `yaml:"user_agent" toml:"user_agent"`
+}
+
+// ProfanityConfig содержит настройки поиска ненормативной лексики
+type ProfanityConfig struct {
+	WordlistsDir string `yaml:"wordlists_dir" toml:"wordlists_dir"` // Каталог собственных списков слов вместо встроенных
+}
+
+// Default возвращает конфигурацию по умолчанию
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:              ":8080",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   20 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Name:            "song-db",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			MigrationsDir:   "./db/migrations",
+		},
+		Timeouts: TimeoutsConfig{
+			Default: 10 * time.Second,
+			Operations: map[string]time.Duration{
+				"/stats":       20 * time.Second,
+				"/songs/merge": 20 * time.Second,
+			},
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Store:   "memory",
+			Default: Rate{Requests: 300, Per: time.Minute},
+			// Поиск по тексту и выборки без индекса дороже точечных запросов
+			Routes: map[string]Rate{
+				"/songs":              {Requests: 60, Per: time.Minute},
+				"/songs/random":       {Requests: 60, Per: time.Minute},
+				"/songs/{id}/similar": {Requests: 60, Per: time.Minute},
+			},
+			APIKeyHeader:   "X-API-Key",
+			TrustedProxies: 1,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "song-library",
+			SampleRatio: 1,
+		},
+		Features: FeaturesConfig{
+			Swagger:      true,
+			LinkChecker:  true,
+			SimilarSongs: true,
+		},
+		LinkChecker: LinkCheckerConfig{
+			Interval:           time.Hour,
+			RecheckAfter:       24 * time.Hour,
+			BatchSize:          100,
+			RequestsPerSecond:  2,
+			PerHostConcurrency: 2,
+			Timeout:            10 * time.Second,
+			BrokenAfter:        3,
+			UserAgent:          "song-library-link-checker/1.0",
+		},
+	}
+}
+
+// Validate проверяет конфигурацию и возвращает все найденные ошибки сразу
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Addr != "", "server.addr is required")
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.read_header_timeout", c.Server.ReadHeaderTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_delay", c.Server.ShutdownDelay},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+		{"database.conn_max_lifetime", c.Database.ConnMaxLifetime},
+		{"database.conn_max_idle_time", c.Database.ConnMaxIdleTime},
+	} {
+		check(timeout.value >= 0, "%s must not be negative", timeout.name)
+	}
+	check((c.Server.TLS.CertFile == "") == (c.Server.TLS.KeyFile == ""),
+		"server.tls.cert_file and server.tls.key_file must be set together")
+
+	check(c.Database.Host != "", "database.host is required")
+	check(c.Database.Port > 0 && c.Database.Port <= 65535, "database.port must be between 1 and 65535")
+	check(c.Database.User != "", "database.user is required")
+	check(c.Database.Name != "", "database.name is required")
+	check(slices.Contains([]string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}, c.Database.SSLMode),
+		"database.sslmode %q is not supported", c.Database.SSLMode)
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns must not be negative")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns must not exceed database.max_open_conns")
+	check(c.Database.MigrationsDir != "", "database.migrations_dir is required")
+
+	check(c.Timeouts.Default >= 0, "timeouts.default must not be negative")
+	for _, pattern := range slices.Sorted(maps.Keys(c.Timeouts.Operations)) {
+		timeout := c.Timeouts.Operations[pattern]
+		check(timeout >= 0, "timeouts.operations[%q] must not be negative", pattern)
+		// Ответ о превышении дедлайна должен успеть уйти до закрытия соединения по write_timeout
+		check(c.Server.WriteTimeout == 0 || timeout < c.Server.WriteTimeout,
+			"timeouts.operations[%q] must be less than server.write_timeout", pattern)
+	}
+	check(c.Server.WriteTimeout == 0 || c.Timeouts.Default < c.Server.WriteTimeout,
+		"timeouts.default must be less than server.write_timeout")
+
+	check(slices.Contains([]string{"memory", "postgres"}, c.RateLimit.Store), "rate_limit.store must be either memory or postgres")
+	check(c.RateLimit.APIKeyHeader != "", "rate_limit.api_key_header is required")
+	check(!c.RateLimit.TrustForwardedFor || c.RateLimit.TrustedProxies > 0,
+		"rate_limit.trusted_proxies must be positive when rate_limit.trust_forwarded_for is set")
+	rates := map[string]Rate{"rate_limit.default": c.RateLimit.Default}
+	for pattern, rate := range c.RateLimit.Routes {
+		rates[fmt.Sprintf("rate_limit.routes[%q]", pattern)] = rate
+	}
+	for _, name := range slices.Sorted(maps.Keys(rates)) {
+		rate := rates[name]
+		check(rate.Requests >= 0, "%s must not be negative", name)
+		check(rate.Requests == 0 || rate.Per > 0, "%s period must be positive", name)
+	}
+
+	check(slices.Contains([]string{"debug", "info", "warn", "error"}, c.Log.Level), "log.level must be one of debug, info, warn, error")
+	check(slices.Contains([]string{"text", "json"}, c.Log.Format), "log.format must be either text or json")
+
+	check(slices.Contains([]string{"none", "stdout", "otlp"}, c.Tracing.Exporter), "tracing.exporter must be one of none, stdout, otlp")
+	check(c.Tracing.ServiceName != "", "tracing.service_name is required")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	check(c.LinkChecker.Interval > 0, "link_checker.interval must be positive")
+	check(c.LinkChecker.RecheckAfter > 0, "link_checker.recheck_after must be positive")
+	check(c.LinkChecker.BatchSize > 0, "link_checker.batch_size must be positive")
+	check(c.LinkChecker.RequestsPerSecond >= 0, "link_checker.requests_per_second must not be negative")
+	check(c.LinkChecker.PerHostConcurrency > 0, "link_checker.per_host_concurrency must be positive")
+	check(c.LinkChecker.Timeout > 0, "link_checker.timeout must be positive")
+	check(c.LinkChecker.BrokenAfter > 0, "link_checker.broken_after must be positive")
+	check(c.LinkChecker.UserAgent != "", "link_checker.user_agent is required")
+
+	return errors.Join(errs...)
+}
+
+// Redacted возвращает копию конфигурации, в которой секреты заменены заглушкой
+func (c Config) Redacted() Config {
+	if c.Database.Password != "" {
+		c.Database.Password = redacted
+	}
+	if len(c.RateLimit.APIKeys) > 0 {
+		keys := make([]string, len(c.RateLimit.APIKeys))
+		for i := range keys {
+			keys[i] = redacted
+		}
+		c.RateLimit.APIKeys = keys
+	}
+	return c
+}
+
+// DSN возвращает строку подключения к PostgreSQL
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		quoteDSN(c.Host), c.Port, quoteDSN(c.User), quoteDSN(c.Password), quoteDSN(c.Name), c.SSLMode)
+}
+
+// quoteDSN экранирует значение параметра строки подключения libpq
+func quoteDSN(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	value = strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return "'" + value + "'"
+}
+
+// parseBool принимает true/false, 1/0, yes/no и on/off
+func parseBool(raw string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "yes", "on":
+		return true, nil
+	case "no", "off":
+		return false, nil
+	}
+	return strconv.ParseBool(raw)
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateLinkChecker(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*LinkCheckerConfig)
+		wantErr string
+	}{
+		{name: "defaults", modify: func(*LinkCheckerConfig) {}},
+		{name: "unlimited requests", modify: func(c *LinkCheckerConfig) { c.RequestsPerSecond = 0 }},
+		{name: "zero interval", modify: func(c *LinkCheckerConfig) { c.Interval = 0 }, wantErr: "link_checker.interval must be positive"},
+		{name: "zero recheck", modify: func(c *LinkCheckerConfig) { c.RecheckAfter = 0 }, wantErr: "link_checker.recheck_after must be positive"},
+		{name: "zero batch", modify: func(c *LinkCheckerConfig) { c.BatchSize = 0 }, wantErr: "link_checker.batch_size must be positive"},
+		{name: "negative rate", modify: func(c *LinkCheckerConfig) { c.RequestsPerSecond = -1 }, wantErr: "link_checker.requests_per_second must not be negative"},
+		{name: "zero per host", modify: func(c *LinkCheckerConfig) { c.PerHostConcurrency = 0 }, wantErr: "link_checker.per_host_concurrency must be positive"},
+		{name: "negative timeout", modify: func(c *LinkCheckerConfig) { c.Timeout = -time.Second }, wantErr: "link_checker.timeout must be positive"},
+		{name: "zero broken after", modify: func(c *LinkCheckerConfig) { c.BrokenAfter = 0 }, wantErr: "link_checker.broken_after must be positive"},
+		{name: "empty user agent", modify: func(c *LinkCheckerConfig) { c.UserAgent = "" }, wantErr: "link_checker.user_agent is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(&cfg.LinkChecker)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// option связывает поле конфигурации с переменной окружения и флагом командной строки
+type option struct {
+	flag   string
+	env    string
+	target any // Указатель на поле: строка, число, bool, длительность, Rate, список строк или карта длительностей и Rate
+	usage  string
+}
+
+// options перечисляет все настройки, доступные через окружение и флаги
+func (c *Config) options() []option {
+	return []option{
+		{"addr", "HTTP_ADDR", &c.Server.Addr, "HTTP listen address"},
+		{"read-timeout", "HTTP_READ_TIMEOUT", &c.Server.ReadTimeout, "HTTP read timeout"},
+		{"read-header-timeout", "HTTP_READ_HEADER_TIMEOUT", &c.Server.ReadHeaderTimeout, "HTTP read header timeout"},
+		{"write-timeout", "HTTP_WRITE_TIMEOUT", &c.Server.WriteTimeout, "HTTP write timeout"},
+		{"idle-timeout", "HTTP_IDLE_TIMEOUT", &c.Server.IdleTimeout, "HTTP keep-alive idle timeout"},
+		{"shutdown-delay", "HTTP_SHUTDOWN_DELAY", &c.Server.ShutdownDelay, "Delay between failing readiness and draining connections"},
+		{"shutdown-timeout", "HTTP_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout, "Graceful shutdown deadline"},
+		{"tls-cert", "TLS_CERT_FILE", &c.Server.TLS.CertFile, "TLS certificate file"},
+		{"tls-key", "TLS_KEY_FILE", &c.Server.TLS.KeyFile, "TLS private key file"},
+
+		{"db-host", "DB_HOST", &c.Database.Host, "PostgreSQL host"},
+		{"db-port", "DB_PORT", &c.Database.Port, "PostgreSQL port"},
+		{"db-user", "DB_USER", &c.Database.User, "PostgreSQL user"},
+		{"db-password", "DB_PASSWORD", &c.Database.Password, "PostgreSQL password"},
+		{"db-name", "DB_NAME", &c.Database.Name, "PostgreSQL database name"},
+		{"db-sslmode", "DB_SSLMODE", &c.Database.SSLMode, "PostgreSQL sslmode"},
+		{"db-max-open-conns", "DB_MAX_OPEN_CONNS", &c.Database.MaxOpenConns, "Maximum open connections (0 = unlimited)"},
+		{"db-max-idle-conns", "DB_MAX_IDLE_CONNS", &c.Database.MaxIdleConns, "Maximum idle connections"},
+		{"db-conn-max-lifetime", "DB_CONN_MAX_LIFETIME", &c.Database.ConnMaxLifetime, "Maximum connection lifetime (0 = unlimited)"},
+		{"db-conn-max-idle-time", "DB_CONN_MAX_IDLE_TIME", &c.Database.ConnMaxIdleTime, "Maximum connection idle time (0 = unlimited)"},
+		{"migrations", "MIGRATIONS_DIR", &c.Database.MigrationsDir, "Path to migrations directory"},
+
+		{"request-timeout", "REQUEST_TIMEOUT", &c.Timeouts.Default, "Default request deadline (0 = none)"},
+		{"operation-timeouts", "OPERATION_TIMEOUTS", &c.Timeouts.Operations, "Per-route deadlines, e.g. /stats=20s,/songs/merge=20s"},
+
+		{"rate-limit", "RATE_LIMIT_ENABLED", &c.RateLimit.Enabled, "Enable per-client rate limiting"},
+		{"rate-limit-store", "RATE_LIMIT_STORE", &c.RateLimit.Store, "Rate limiter state: memory or postgres"},
+		{"rate-limit-default", "RATE_LIMIT_DEFAULT", &c.RateLimit.Default, "Default limit per client and route, e.g. 300/m"},
+		{"rate-limit-routes", "RATE_LIMIT_ROUTES", &c.RateLimit.Routes, "Per-route limits, e.g. /songs=60/m,/songs/info=600/m"},
+		{"rate-limit-api-key-header", "RATE_LIMIT_API_KEY_HEADER", &c.RateLimit.APIKeyHeader, "Header carrying the client API key"},
+		{"rate-limit-api-keys", "RATE_LIMIT_API_KEYS", &c.RateLimit.APIKeys, "Comma-separated known API keys"},
+		{"rate-limit-trust-forwarded-for", "RATE_LIMIT_TRUST_FORWARDED_FOR", &c.RateLimit.TrustForwardedFor, "Take client IP from X-Forwarded-For"},
+		{"rate-limit-trusted-proxies", "RATE_LIMIT_TRUSTED_PROXIES", &c.RateLimit.TrustedProxies, "Number of trusted proxies appending to X-Forwarded-For"},
+
+		{"log-level", "LOG_LEVEL", &c.Log.Level, "Log level: debug, info, warn or error"},
+		{"log-format", "LOG_FORMAT", &c.Log.Format, "Log format: text or json"},
+
+		{"tracing-exporter", "TRACING_EXPORTER", &c.Tracing.Exporter, "Trace exporter: none, stdout or otlp"},
+		{"tracing-endpoint", "TRACING_ENDPOINT", &c.Tracing.Endpoint, "OTLP/HTTP endpoint URL, e.g. http://localhost:4318"},
+		{"tracing-service-name", "TRACING_SERVICE_NAME", &c.Tracing.ServiceName, "Service name reported in traces"},
+		{"tracing-sample-ratio", "TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio, "Fraction of traces to record, 0 to 1"},
+
+		{"swagger", "FEATURE_SWAGGER", &c.Features.Swagger, "Serve Swagger UI"},
+		{"link-checker", "FEATURE_LINK_CHECKER", &c.Features.LinkChecker, "Run background link checker"},
+		{"similar-songs", "FEATURE_SIMILAR_SONGS", &c.Features.SimilarSongs, "Build similar songs index"},
+
+		{"link-check-interval", "LINK_CHECK_INTERVAL", &c.LinkChecker.Interval, "Pause between link checker passes"},
+		{"link-check-recheck-after", "LINK_CHECK_RECHECK_AFTER", &c.LinkChecker.RecheckAfter, "Age after which a checked link is checked again"},
+		{"link-check-batch-size", "LINK_CHECK_BATCH_SIZE", &c.LinkChecker.BatchSize, "Links checked per pass"},
+		{"link-check-rps", "LINK_CHECK_RPS", &c.LinkChecker.RequestsPerSecond, "Link checker requests per second (0 = unlimited)"},
+		{"link-check-per-host", "LINK_CHECK_PER_HOST", &c.LinkChecker.PerHostConcurrency, "Concurrent link checker requests per host"},
+		{"link-check-timeout", "LINK_CHECK_TIMEOUT", &c.LinkChecker.Timeout, "Timeout of a single link check request"},
+		{"link-check-broken-after", "LINK_CHECK_BROKEN_AFTER", &c.LinkChecker.BrokenAfter, "Consecutive failures before a link is marked broken"},
+		{"link-check-user-agent", "LINK_CHECK_USER_AGENT", &c.LinkChecker.UserAgent, "User-Agent of link checker requests"},
+
+		{"profanity-wordlists", "PROFANITY_WORDLISTS_DIR", &c.Profanity.WordlistsDir, "Directory with custom profanity word lists"},
+	}
+}
+
+// ErrPrintConfig возвращается вместе с загруженной конфигурацией, если указан флаг -print-config:
+// вызывающий выводит конфигурацию (Config.String) и завершает работу
+var ErrPrintConfig = errors.New("print-config requested")
+
+// Load загружает конфигурацию из файла, окружения и аргументов командной строки args
+func Load(args []string) (*Config, error) {
+	return LoadWithFlags(flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ContinueOnError), args)
+}
+
+// LoadWithFlags регистрирует флаги конфигурации в flags рядом с собственными флагами команды
+// и загружает конфигурацию. Путь к файлу задаётся флагом -config или переменной CONFIG_FILE.
+// Файл .env необязателен: если он есть, его переменные дополняют окружение
+func LoadWithFlags(flags *flag.FlagSet, args []string) (*Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("load .env: %w", err)
+	}
+
+	cfg := Default()
+	options := cfg.options()
+
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "Path to YAML or TOML config file")
+	printConfig := flags.Bool("print-config", false, "Print effective configuration and exit")
+	raw := make(map[string]*string, len(options))
+	for _, opt := range options {
+		raw[opt.flag] = flags.String(opt.flag, "", opt.usage+" (env "+opt.env+")")
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, opt := range options {
+		if value, ok := os.LookupEnv(opt.env); ok && value != "" {
+			if err := setValue(opt.target, value); err != nil {
+				return nil, fmt.Errorf("env %s: %w", opt.env, err)
+			}
+		}
+	}
+
+	// Применяются только явно указанные флаги, чтобы пустые значения не затирали окружение
+	var flagErr error
+	flags.Visit(func(f *flag.Flag) {
+		for _, opt := range options {
+			if opt.flag == f.Name && flagErr == nil {
+				if err := setValue(opt.target, *raw[opt.flag]); err != nil {
+					flagErr = fmt.Errorf("flag -%s: %w", opt.flag, err)
+				}
+			}
+		}
+	})
+	if flagErr != nil {
+		return nil, flagErr
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if *printConfig {
+		return cfg, ErrPrintConfig
+	}
+	return cfg, nil
+}
+
+// loadFile читает настройки из файла YAML или TOML; формат определяется по расширению
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), c)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("parse %s: unknown keys %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("config file %s: unsupported format %q, expected .yaml, .yml or .toml", path, ext)
+	}
+	return nil
+}
+
+// String возвращает конфигурацию в формате YAML со скрытыми секретами
+func (c *Config) String() string {
+	data, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Sprintf("<config: %v>", err)
+	}
+	return string(data)
+}
+
+// setValue записывает строковое значение в поле конфигурации
+func setValue(target any, raw string) error {
+	switch ptr := target.(type) {
+	case *string:
+		*ptr = raw
+	case *int:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		*ptr = value
+	case *float64:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		*ptr = value
+	case *bool:
+		value, err := parseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		*ptr = value
+	case *time.Duration:
+		value, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		*ptr = value
+	case *Rate:
+		return ptr.UnmarshalText([]byte(raw))
+	case *[]string:
+		// Формат: значения через запятую; заменяет заданный ранее список
+		*ptr = nil
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				*ptr = append(*ptr, value)
+			}
+		}
+	case *map[string]time.Duration:
+		return setMap(ptr, raw, time.ParseDuration)
+	case *map[string]Rate:
+		return setMap(ptr, raw, func(value string) (Rate, error) {
+			var rate Rate
+			err := rate.UnmarshalText([]byte(value))
+			return rate, err
+		})
+	default:
+		return fmt.Errorf("unsupported option type %T", target)
+	}
+	return nil
+}
+
+// setMap разбирает пары ключ=значение через запятую; значения дополняют и переопределяют заданные ранее
+func setMap[V any](target *map[string]V, raw string, parse func(string) (V, error)) error {
+	if *target == nil {
+		*target = make(map[string]V)
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid entry %q, expected key=value", pair)
+		}
+		parsed, err := parse(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+		}
+		(*target)[key] = parsed
+	}
+	return nil
+}
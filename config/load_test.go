@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadWithFlagsLayering(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	yamlFile := writeFile("config.yaml", "server:\n  addr: \":9000\"\n  read_timeout: 7s\n")
+	tomlFile := writeFile("config.toml", "[server]\naddr = \":9001\"\n")
+	unknownKey := writeFile("unknown.yaml", "server:\n  adress: \":9000\"\n")
+	iniFile := writeFile("config.ini", "addr = :9000\n")
+
+	tests := []struct {
+		name        string
+		env         map[string]string
+		args        []string
+		wantAddr    string
+		wantTimeout time.Duration
+		wantErr     string
+	}{
+		{name: "defaults", wantAddr: ":8080", wantTimeout: 15 * time.Second},
+		{name: "yaml file", args: []string{"-config", yamlFile}, wantAddr: ":9000", wantTimeout: 7 * time.Second},
+		{name: "toml file", args: []string{"-config", tomlFile}, wantAddr: ":9001", wantTimeout: 15 * time.Second},
+		{name: "file from env", env: map[string]string{"CONFIG_FILE": yamlFile}, wantAddr: ":9000", wantTimeout: 7 * time.Second},
+		{
+			name:        "env overrides file",
+			env:         map[string]string{"HTTP_ADDR": ":9100"},
+			args:        []string{"-config", yamlFile},
+			wantAddr:    ":9100",
+			wantTimeout: 7 * time.Second,
+		},
+		{
+			name:        "flag overrides env",
+			env:         map[string]string{"HTTP_ADDR": ":9100", "HTTP_READ_TIMEOUT": "3s"},
+			args:        []string{"-config", yamlFile, "-addr", ":9200"},
+			wantAddr:    ":9200",
+			wantTimeout: 3 * time.Second,
+		},
+		{name: "empty env ignored", env: map[string]string{"HTTP_ADDR": ""}, wantAddr: ":8080", wantTimeout: 15 * time.Second},
+		{name: "invalid env value", env: map[string]string{"HTTP_READ_TIMEOUT": "soon"}, wantErr: "env HTTP_READ_TIMEOUT"},
+		{name: "invalid flag value", args: []string{"-read-timeout", "soon"}, wantErr: "flag -read-timeout"},
+		{name: "unknown file key", args: []string{"-config", unknownKey}, wantErr: "field adress not found"},
+		{name: "missing file", args: []string{"-config", filepath.Join(dir, "missing.yaml")}, wantErr: "read config file"},
+		{name: "unsupported format", args: []string{"-config", iniFile}, wantErr: "unsupported format"},
+		{name: "failed validation", args: []string{"-read-timeout", "-1s"}, wantErr: "server.read_timeout must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"CONFIG_FILE", "HTTP_ADDR", "HTTP_READ_TIMEOUT"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			cfg, err := LoadWithFlags(flags, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadWithFlags() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadWithFlags() error = %v", err)
+			}
+			if cfg.Server.Addr != tt.wantAddr || cfg.Server.ReadTimeout != tt.wantTimeout {
+				t.Errorf("addr = %q, read_timeout = %v, want %q, %v",
+					cfg.Server.Addr, cfg.Server.ReadTimeout, tt.wantAddr, tt.wantTimeout)
+			}
+		})
+	}
+}
+
+func TestLoadWithFlagsLinkChecker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("link_checker:\n  batch_size: 20\n  requests_per_second: 0.5\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("LINK_CHECK_INTERVAL", "15m")
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	cfg, err := LoadWithFlags(flags, []string{"-config", path, "-link-check-per-host", "4"})
+	if err != nil {
+		t.Fatalf("LoadWithFlags() error = %v", err)
+	}
+	want := Default().LinkChecker
+	want.BatchSize, want.RequestsPerSecond, want.Interval, want.PerHostConcurrency = 20, 0.5, 15*time.Minute, 4
+	if cfg.LinkChecker != want {
+		t.Errorf("link_checker = %+v, want %+v", cfg.LinkChecker, want)
+	}
+}
+
+func TestLoadWithFlagsPrintConfig(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("DB_PASSWORD", "secret")
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg, err := LoadWithFlags(flags, []string{"-print-config"})
+	if !errors.Is(err, ErrPrintConfig) {
+		t.Fatalf("LoadWithFlags() error = %v, want ErrPrintConfig", err)
+	}
+	if cfg == nil {
+		t.Fatal("configuration not returned with ErrPrintConfig")
+	}
+	if strings.Contains(cfg.String(), "secret") {
+		t.Error("printed configuration contains the database password")
+	}
+}
@@ -1,61 +1,94 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	_ "github.com/lib/pq" // PostgreSQL драйвер
+	"github.com/lib/pq" // PostgreSQL драйвер
 	"github.com/pressly/goose/v3"
-	"log"
+	"log/slog"
+	"song-libary/config"
+	"song-libary/tracing"
 )
 
 type DbManager struct {
-	DB *sql.DB
+	DB     *sql.DB
+	Logger *slog.Logger
 }
 
-func NewDbManager() *DbManager {
-	return &DbManager{DB: &sql.DB{}}
+func NewDbManager(logger *slog.Logger) *DbManager {
+	return &DbManager{DB: &sql.DB{}, Logger: logger}
 }
 
-// InitDB инициализирует соединение с базой данных
-func (d *DbManager) InitDB(host, port, user, password, dbname string) error {
-	log.Println("[INFO] Initializing database connection...")
+// InitDB инициализирует соединение с базой данных и настраивает пул соединений
+func (d *DbManager) InitDB(ctx context.Context, cfg config.DatabaseConfig) error {
+	d.Logger.InfoContext(ctx, "Initializing database connection")
 
 	// Формируем DSN (Data Source Name)
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		host, port, user, password, dbname,
-	)
-	log.Printf("[DEBUG] DSN: %s", dsn)
-
-	// Подключение к базе данных
-	var err error
-	d.DB, err = sql.Open("postgres", dsn)
+	dsn := cfg.DSN()
+	d.Logger.DebugContext(ctx, "Connecting to database", "host", cfg.Host, "port", cfg.Port, "user", cfg.User,
+		"dbname", cfg.Name, "sslmode", cfg.SSLMode)
+
+	// Подключение к базе данных; каждый SQL-запрос получает span трассировки
+	connector, err := pq.NewConnector(dsn)
 	if err != nil {
-		log.Printf("[ERROR] Failed to open database connection: %v", err)
+		d.Logger.ErrorContext(ctx, "Failed to open database connection", "error", err)
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
+	d.DB = sql.OpenDB(tracing.WrapConnector(connector))
+	d.DB.SetMaxOpenConns(cfg.MaxOpenConns)
+	d.DB.SetMaxIdleConns(cfg.MaxIdleConns)
+	d.DB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	d.DB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
 	// Проверка доступности базы данных
-	if err = d.DB.Ping(); err != nil {
-		log.Printf("[ERROR] Database is unreachable: %v", err)
+	if err = d.DB.PingContext(ctx); err != nil {
+		d.Logger.ErrorContext(ctx, "Database is unreachable", "error", err)
 		return fmt.Errorf("database is unreachable: %w", err)
 	}
 
-	log.Println("[INFO] Database connection established")
+	d.Logger.InfoContext(ctx, "Database connection established")
 	return nil
 }
 
 // ApplyMigrations применяет миграции
-func (d *DbManager) ApplyMigrations(migrationsDir string) error {
-	log.Printf("[INFO] Applying migrations from directory: %s", migrationsDir)
+func (d *DbManager) ApplyMigrations(ctx context.Context, migrationsDir string) error {
+	d.Logger.InfoContext(ctx, "Applying migrations from directory", "migrations_dir", migrationsDir)
 	goose.SetDialect("postgres")
 
 	// Применение миграций
-	if err := goose.Up(d.DB, migrationsDir); err != nil {
-		log.Printf("[ERROR] Failed to apply migrations: %v", err)
+	if err := goose.UpContext(ctx, d.DB, migrationsDir); err != nil {
+		d.Logger.ErrorContext(ctx, "Failed to apply migrations", "error", err)
+		return err
+	}
+
+	d.Logger.InfoContext(ctx, "Migrations applied successfully")
+	return nil
+}
+
+// Close закрывает пул соединений с базой данных
+func (d *DbManager) Close() error {
+	d.Logger.Info("Closing database connections")
+	if err := d.DB.Close(); err != nil {
+		d.Logger.Error("Failed to close database", "error", err)
 		return err
 	}
 
-	log.Println("[INFO] Migrations applied successfully")
+	d.Logger.Info("Database connections closed")
 	return nil
 }
+
+// PendingMigrations возвращает количество миграций из каталога, которые ещё не применены
+func (d *DbManager) PendingMigrations(ctx context.Context, migrationsDir string) (int, error) {
+	current, err := goose.GetDBVersionContext(ctx, d.DB)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get migration version: %w", err)
+	}
+
+	pending, err := goose.CollectMigrations(migrationsDir, current, goose.MaxVersion)
+	if err != nil && !errors.Is(err, goose.ErrNoMigrationFiles) {
+		return 0, fmt.Errorf("failed to collect migrations: %w", err)
+	}
+	return len(pending), nil
+}
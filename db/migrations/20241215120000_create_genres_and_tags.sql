@@ -0,0 +1,40 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS genres (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    parent_id INT REFERENCES genres(id) ON DELETE SET NULL
+    );
+
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE
+    );
+
+CREATE TABLE IF NOT EXISTS song_genres (
+    song_id UUID NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    genre_id INT NOT NULL REFERENCES genres(id) ON DELETE CASCADE,
+    PRIMARY KEY (song_id, genre_id)
+    );
+
+CREATE TABLE IF NOT EXISTS song_tags (
+    song_id UUID NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (song_id, tag_id)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_genres_parent_id ON genres (parent_id);
+CREATE INDEX IF NOT EXISTS idx_song_genres_genre_id ON song_genres (genre_id);
+CREATE INDEX IF NOT EXISTS idx_song_tags_tag_id ON song_tags (tag_id);
+
+INSERT INTO genres (name) VALUES ('rock'), ('pop'), ('electronic'), ('folk'), ('metal');
+INSERT INTO genres (name, parent_id)
+SELECT child.name, parent.id
+FROM (VALUES ('alt-rock', 'rock'), ('hard-rock', 'rock'), ('indie-rock', 'rock'),
+             ('synth-pop', 'pop'), ('ambient', 'electronic')) AS child(name, parent_name)
+JOIN genres parent ON parent.name = child.parent_name;
+
+-- +goose Down
+DROP TABLE IF EXISTS song_tags;
+DROP TABLE IF EXISTS song_genres;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS genres;
@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS artists (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE
+    );
+
+CREATE TABLE IF NOT EXISTS song_credits (
+    song_id UUID NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    artist_id INT NOT NULL REFERENCES artists(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('primary', 'featured', 'composer', 'lyricist', 'producer')),
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (song_id, artist_id, role)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_song_credits_artist_id ON song_credits (artist_id);
+
+-- Исполнитель каждой существующей песни становится её основным автором
+INSERT INTO artists (name)
+SELECT DISTINCT group_name FROM songs
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO song_credits (song_id, artist_id, role)
+SELECT s.id, a.id, 'primary'
+FROM songs s JOIN artists a ON a.name = s.group_name
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS song_credits;
+DROP TABLE IF EXISTS artists;
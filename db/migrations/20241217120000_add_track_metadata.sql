@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE songs
+    ADD COLUMN IF NOT EXISTS duration_ms INT CHECK (duration_ms > 0),
+    ADD COLUMN IF NOT EXISTS isrc VARCHAR(12),
+    ADD COLUMN IF NOT EXISTS bpm SMALLINT CHECK (bpm > 0),
+    ADD COLUMN IF NOT EXISTS musical_key VARCHAR(3) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS explicit BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_isrc ON songs (isrc) WHERE isrc IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_songs_bpm ON songs (bpm);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_songs_bpm;
+DROP INDEX IF EXISTS idx_songs_isrc;
+ALTER TABLE songs
+    DROP COLUMN IF EXISTS explicit,
+    DROP COLUMN IF EXISTS musical_key,
+    DROP COLUMN IF EXISTS bpm,
+    DROP COLUMN IF EXISTS isrc,
+    DROP COLUMN IF EXISTS duration_ms;
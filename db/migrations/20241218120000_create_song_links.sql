@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS song_links (
+    id SERIAL PRIMARY KEY,
+    song_id UUID NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,
+    url TEXT NOT NULL,
+    external_id VARCHAR(200) NOT NULL DEFAULT '',
+    region VARCHAR(2) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (song_id, url)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_song_links_song_id ON song_links (song_id);
+
+-- Переносим существующие ссылки. Здесь определяется только провайдер: идентификатор трека и регион
+-- остаются пустыми, пока ссылку не сохранят заново через API (SaveSong/UpdateSong синхронизируют song_links)
+INSERT INTO song_links (song_id, provider, url)
+SELECT id,
+       CASE
+           WHEN link ~* '^https?://([a-z0-9-]+\.)*(youtube\.com|youtu\.be|youtube-nocookie\.com)/' THEN 'youtube'
+           WHEN link ~* '^https?://open\.spotify\.com/' THEN 'spotify'
+           WHEN link ~* '^https?://music\.apple\.com/' THEN 'apple_music'
+           WHEN link ~* '^https?://([a-z0-9-]+\.)?bandcamp\.com/' THEN 'bandcamp'
+           ELSE 'other'
+       END,
+       link
+FROM songs
+WHERE link <> ''
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS song_links;
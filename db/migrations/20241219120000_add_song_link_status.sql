@@ -0,0 +1,18 @@
+-- +goose Up
+ALTER TABLE song_links
+    ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'unchecked' CHECK (status IN ('unchecked', 'ok', 'broken')),
+    ADD COLUMN IF NOT EXISTS status_code INT,
+    ADD COLUMN IF NOT EXISTS last_checked_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS failure_streak INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_song_links_last_checked_at ON song_links (last_checked_at NULLS FIRST);
+CREATE INDEX IF NOT EXISTS idx_song_links_status ON song_links (status);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_song_links_status;
+DROP INDEX IF EXISTS idx_song_links_last_checked_at;
+ALTER TABLE song_links
+    DROP COLUMN IF EXISTS failure_streak,
+    DROP COLUMN IF EXISTS last_checked_at,
+    DROP COLUMN IF EXISTS status_code,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS song_translations (
+    id SERIAL PRIMARY KEY,
+    song_id UUID NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    lang VARCHAR(16) NOT NULL,
+    text TEXT NOT NULL,
+    translator VARCHAR(100) NOT NULL DEFAULT '',
+    status VARCHAR(10) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'review', 'published')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (song_id, lang)
+    );
+
+-- +goose Down
+DROP TABLE IF EXISTS song_translations;
@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE songs
+    ADD COLUMN IF NOT EXISTS language VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS language_confidence REAL NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS language_manual BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_songs_language ON songs (language);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_songs_language;
+ALTER TABLE songs
+    DROP COLUMN IF EXISTS language_manual,
+    DROP COLUMN IF EXISTS language_confidence,
+    DROP COLUMN IF EXISTS language;
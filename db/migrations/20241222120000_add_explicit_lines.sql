@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE songs
+    ADD COLUMN IF NOT EXISTS explicit_lines INT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_songs_explicit ON songs (explicit);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_songs_explicit;
+ALTER TABLE songs
+    DROP COLUMN IF EXISTS explicit_lines;
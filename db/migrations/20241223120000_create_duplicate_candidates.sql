@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS duplicate_candidates (
+    id SERIAL PRIMARY KEY,
+    song_id UUID NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    duplicate_id UUID NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    similarity REAL NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'dismissed')),
+    created_at TIMESTAMP NOT NULL DEFAULT timezone('UTC', now()),
+    UNIQUE (song_id, duplicate_id),
+    CHECK (song_id < duplicate_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_duplicate_candidates_status ON duplicate_candidates (status);
+
+-- +goose Down
+DROP TABLE IF EXISTS duplicate_candidates;
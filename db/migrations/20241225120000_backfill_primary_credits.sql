@@ -0,0 +1,16 @@
+-- +goose Up
+-- Песни, добавленные или переименованные после создания song_credits, получают основного исполнителя
+-- по текущему названию группы; запись ставится первой
+INSERT INTO artists (name)
+SELECT DISTINCT group_name FROM songs
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO song_credits (song_id, artist_id, role, position)
+SELECT s.id, a.id, 'primary',
+       COALESCE((SELECT MIN(sc.position) - 1 FROM song_credits sc WHERE sc.song_id = s.id), 0)
+FROM songs s JOIN artists a ON a.name = s.group_name
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+-- Восстановленные записи не отличить от добавленных вручную, поэтому откат ничего не удаляет
+SELECT 1;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Время, раньше которого ссылку не нужно проверять: хост попросил подождать ответом 429
+ALTER TABLE song_links
+    ADD COLUMN IF NOT EXISTS next_check_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE song_links
+    DROP COLUMN IF EXISTS next_check_at;
@@ -15,60 +15,43 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/songs": {
+        "/duplicates": {
             "get": {
-                "description": "Возвращает список песен с возможностью фильтрации по группе, названию, тексту и дате релиза(вернёт все песни, которые вышли в релиз раньше), а также с пагинацией",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает пары песен с одинаковыми нормализованными названием и группой и близкими текстами, найденные командой dupscan, начиная с самых похожих",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Песни"
+                    "Дубликаты"
                 ],
-                "summary": "Получение песен с фильтрацией и пагинацией",
+                "summary": "Кандидаты в дубликаты",
                 "parameters": [
                     {
+                        "enum": [
+                            "pending",
+                            "dismissed"
+                        ],
                         "type": "string",
-                        "example": "\"Muse\"",
-                        "description": "Название группы",
-                        "name": "group",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"Hysteria\"",
-                        "description": "Название песни",
-                        "name": "song",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"It's bugging me, grating me\"",
-                        "description": "Текст песни",
-                        "name": "text",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"2003-12-15\"",
-                        "description": "Дата релиза",
-                        "name": "release_date",
+                        "default": "pending",
+                        "description": "Статус проверки",
+                        "name": "status",
                         "in": "query"
                     },
                     {
+                        "maximum": 100,
+                        "minimum": 1,
                         "type": "integer",
-                        "default": 10,
-                        "example": 5,
-                        "description": "Лимит песен на страницу",
+                        "default": 20,
+                        "example": 10,
+                        "description": "Лимит пар на страницу",
                         "name": "limit",
                         "in": "query"
                     },
                     {
+                        "minimum": 0,
                         "type": "integer",
                         "default": 0,
-                        "example": 10,
+                        "example": 0,
                         "description": "Смещение для пагинации",
                         "name": "offset",
                         "in": "query"
@@ -76,56 +59,70 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список песен",
+                        "description": "Кандидаты в дубликаты",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Song"
+                                "$ref": "#/definitions/models.DuplicateCandidate"
                             }
                         }
                     },
                     "400": {
                         "description": "Ошибка в запросе",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/songs/add": {
+        "/duplicates/{id}/dismiss": {
             "post": {
-                "description": "Добавление новой песни в музыкальную библиотеку",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Отмечает пару как разные песни; повторное сканирование не вернёт её на проверку",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Песни"
+                    "Дубликаты"
                 ],
-                "summary": "Добавление новой песни",
+                "summary": "Отклонение кандидата в дубликаты",
                 "parameters": [
                     {
-                        "description": "Детали новой песни",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AddSongRequest"
-                        }
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Идентификатор кандидата",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Песня успешно добавлена",
+                    "200": {
+                        "description": "Кандидат отклонён",
                         "schema": {
                             "$ref": "#/definitions/models.DefaultResponse"
                         }
@@ -133,80 +130,92 @@ const docTemplate = `{
                     "400": {
                         "description": "Ошибка в запросе",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Кандидат не найден",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/songs/delete": {
-            "delete": {
-                "description": "Удаление песни из музыкальной библиотеки по названию и имени группы",
-                "consumes": [
-                    "application/json"
-                ],
+        "/genres": {
+            "get": {
+                "description": "Возвращает все жанры; иерархия задаётся полем parent_id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Песни"
-                ],
-                "summary": "Удаление песни",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "\"Supermassive Black Hole\"",
-                        "description": "Название песни",
-                        "name": "song_name",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"Muse\"",
-                        "description": "Название группы",
-                        "name": "group",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Жанры и теги"
                 ],
+                "summary": "Получение справочника жанров",
                 "responses": {
                     "200": {
-                        "description": "Песня успешно удалена",
+                        "description": "Список жанров",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Genre"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Ошибка в запросе",
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Песня не найдена",
+                    "500": {
+                        "description": "Ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сервера",
+                    "503": {
+                        "description": "База данных недоступна",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/songs/info": {
-            "get": {
-                "description": "Возвращает информацию о песне, включая дату релиза, текст и ссылку",
+        "/genres/add": {
+            "post": {
+                "description": "Добавление жанра в справочник; parent задаёт родительский жанр (например, rock для alt-rock)",
                 "consumes": [
                     "application/json"
                 ],
@@ -214,222 +223,2955 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Песни"
+                    "Жанры и теги"
                 ],
-                "summary": "Получение информации о песне",
+                "summary": "Добавление жанра",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"Imagine Dragons\"",
-                        "description": "Название группы",
-                        "name": "group",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"Radioactive\"",
-                        "description": "Название песни",
-                        "name": "song_name",
-                        "in": "query",
-                        "required": true
+                        "description": "Новый жанр",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddGenreRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Детали песни",
+                    "201": {
+                        "description": "Жанр успешно добавлен",
                         "schema": {
-                            "$ref": "#/definitions/models.SongDetail"
+                            "$ref": "#/definitions/models.Genre"
                         }
                     },
                     "400": {
                         "description": "Ошибка в запросе",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Родительский жанр не найден",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Жанр уже есть в справочнике",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/songs/text": {
+        "/groups/{group}/stats": {
             "get": {
-                "description": "Возвращает текст песни с разбивкой на куплеты и поддержкой пагинации",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает статистику текстов, объединённую по всем песням группы",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Песни"
+                    "Статистика"
                 ],
-                "summary": "Получение текста песни с пагинацией",
+                "summary": "Статистика текстов группы",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"Bohemian Rhapsody\"",
-                        "description": "Название песни",
-                        "name": "song_name",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"Queen\"",
+                        "example": "\"Muse\"",
                         "description": "Название группы",
                         "name": "group",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "default": 3,
-                        "example": 2,
-                        "description": "Лимит куплетов на страницу",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "example": 1,
-                        "description": "Смещение для пагинации",
-                        "name": "offset",
+                        "default": 10,
+                        "example": 5,
+                        "description": "Количество самых частых слов",
+                        "name": "top",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Текст песни с пагинацией",
+                        "description": "Статистика текстов группы",
                         "schema": {
-                            "$ref": "#/definitions/models.SongTextResponse"
+                            "$ref": "#/definitions/models.GroupStatsResponse"
                         }
                     },
                     "400": {
                         "description": "Ошибка в запросе",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "404": {
-                        "description": "Песня не найдена",
+                        "description": "Песни группы не найдены",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/songs/update": {
-            "put": {
-                "description": "Обновление информации о песне, включая название, группу и текст",
-                "consumes": [
-                    "application/json"
-                ],
+        "/healthz": {
+            "get": {
+                "description": "Возвращает 200, пока процесс обслуживает запросы. Зависимости не проверяются",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Песни"
+                    "Состояние"
                 ],
-                "summary": "Обновление данных песни",
-                "parameters": [
-                    {
-                        "description": "Обновленные данные песни",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "Проверка живости",
+                "responses": {
+                    "200": {
+                        "description": "Процесс работает",
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateSongRequest"
+                            "$ref": "#/definitions/models.HealthResponse"
                         }
                     }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Проверяет подключение к базе данных, отсутствие непримененных миграций и другие зависимости. Возвращает результат и время каждой проверки; во время остановки сервера всегда возвращает 503",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Состояние"
                 ],
+                "summary": "Проверка готовности",
                 "responses": {
                     "200": {
-                        "description": "Песня успешно обновлена",
+                        "description": "Сервис готов",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.HealthResponse"
                         }
                     },
-                    "400": {
-                        "description": "Ошибка в запросе",
+                    "503": {
+                        "description": "Сервис не готов",
                         "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                            "$ref": "#/definitions/models.HealthResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Песня не найдена",
-                        "schema": {
-                            "$ref": "#/definitions/models.DefaultResponse"
+                    }
+                }
+            }
+        },
+        "/songs": {
+            "get": {
+                "description": "Возвращает список песен с возможностью фильтрации по группе, названию, тексту и дате релиза(вернёт все песни, которые вышли в релиз раньше), а также с пагинацией",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Песни"
+                ],
+                "summary": "Получение песен с фильтрацией и пагинацией",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Muse\"",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Hysteria\"",
+                        "description": "Название песни",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"It's bugging me, grating me\"",
+                        "description": "Текст песни",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2003-12-15\"",
+                        "description": "Дата релиза",
+                        "name": "release_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"rock\"",
+                        "description": "Жанр (включая поджанры)",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ballad,live\"",
+                        "description": "Теги через запятую",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Режим совпадения тегов",
+                        "name": "tags_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Matthew Bellamy\"",
+                        "description": "Участник работы над песней (исполнитель, автор, продюсер)",
+                        "name": "credited",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 110,
+                        "description": "Минимальный темп",
+                        "name": "bpm_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 130,
+                        "description": "Максимальный темп",
+                        "name": "bpm_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 180000,
+                        "description": "Минимальная длительность, мс",
+                        "name": "duration_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 300000,
+                        "description": "Максимальная длительность, мс",
+                        "name": "duration_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"C#m\"",
+                        "description": "Тональность",
+                        "name": "key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"en\"",
+                        "description": "Язык текста (ISO 639-1)",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "unchecked",
+                            "ok",
+                            "broken"
+                        ],
+                        "type": "string",
+                        "description": "Песни со ссылками в указанном состоянии",
+                        "name": "link_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Исключить песни с ненормативной лексикой",
+                        "name": "no_explicit",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "Лимит песен на страницу",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "example": 10,
+                        "description": "Смещение для пагинации",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список песен",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Song"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/add": {
+            "post": {
+                "description": "Добавление новой песни в музыкальную библиотеку",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Песни"
+                ],
+                "summary": "Добавление новой песни",
+                "parameters": [
+                    {
+                        "description": "Детали новой песни",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddSongRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Песня успешно добавлена",
                         "schema": {
                             "$ref": "#/definitions/models.DefaultResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "ISRC уже занят",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/credits": {
+            "get": {
+                "description": "Возвращает исполнителей, приглашённых артистов, композиторов, авторов текста и продюсеров песни",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Участники"
+                ],
+                "summary": "Получение участников работы над песней",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Hysteria\"",
+                        "description": "Название песни",
+                        "name": "song_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Muse\"",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список участников",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Credit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/credits/update": {
+            "put": {
+                "description": "Полностью заменяет список участников песни; порядок в запросе сохраняется",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Участники"
+                ],
+                "summary": "Изменение участников работы над песней",
+                "parameters": [
+                    {
+                        "description": "Песня и новый список участников",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetCreditsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Участники успешно обновлены",
+                        "schema": {
+                            "$ref": "#/definitions/models.DefaultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/delete": {
+            "delete": {
+                "description": "Удаление песни из музыкальной библиотеки по названию и имени группы",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Песни"
+                ],
+                "summary": "Удаление песни",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Supermassive Black Hole\"",
+                        "description": "Название песни",
+                        "name": "song_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Muse\"",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Песня успешно удалена",
+                        "schema": {
+                            "$ref": "#/definitions/models.DefaultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/info": {
+            "get": {
+                "description": "Возвращает информацию о песне, включая дату релиза, текст, жанры, теги, участников и ссылки, сгруппированные по провайдеру",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Песни"
+                ],
+                "summary": "Получение информации о песне",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Imagine Dragons\"",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Radioactive\"",
+                        "description": "Название песни",
+                        "name": "song_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Детали песни",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/links": {
+            "get": {
+                "description": "Возвращает ссылки на песню в стриминговых сервисах, сгруппированные по провайдеру",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ссылки"
+                ],
+                "summary": "Получение ссылок на песню",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Hysteria\"",
+                        "description": "Название песни",
+                        "name": "song_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Muse\"",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ссылки по провайдерам",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/models.SongLink"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/links/add": {
+            "post": {
+                "description": "Добавляет ссылку на YouTube, Spotify, Apple Music, Bandcamp или другой сервис. Провайдер и идентификатор трека определяются автоматически",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ссылки"
+                ],
+                "summary": "Добавление ссылки на песню",
+                "parameters": [
+                    {
+                        "description": "Песня и ссылка",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddSongLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Ссылка успешно добавлена",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongLink"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректная ссылка",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Ссылка уже добавлена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/links/delete": {
+            "delete": {
+                "description": "Удаляет ссылку по её идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ссылки"
+                ],
+                "summary": "Удаление ссылки на песню",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Идентификатор ссылки",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ссылка успешно удалена",
+                        "schema": {
+                            "$ref": "#/definitions/models.DefaultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Ссылка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/links/report": {
+            "get": {
+                "description": "Возвращает ссылки, которые не отвечают несколько проверок подряд, с HTTP-статусом, временем последней проверки и числом неудач",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ссылки"
+                ],
+                "summary": "Отчёт о нерабочих ссылках",
+                "responses": {
+                    "200": {
+                        "description": "Нерабочие ссылки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BrokenLink"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/merge": {
+            "post": {
+                "description": "Переносит жанры, теги, участников, ссылки и переводы дубликата на оставляемую песню и удаляет дубликат. Поля из fields берутся у дубликата, остальные остаются у оставляемой песни",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Дубликаты"
+                ],
+                "summary": "Слияние песен",
+                "parameters": [
+                    {
+                        "description": "Песни и поля, которые берутся у дубликата",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergeSongsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Песня после слияния",
+                        "schema": {
+                            "$ref": "#/definitions/models.Song"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "ISRC уже занят",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/on-this-day": {
+            "get": {
+                "description": "Возвращает песни, дата релиза которых совпадает с date по месяцу и дню, от старых к новым. Без date используется сегодняшний день",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Песни"
+                ],
+                "summary": "Песни, вышедшие в этот день",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"07-16\"",
+                        "description": "Дата в формате YYYY-MM-DD или MM-DD",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "Лимит песен на страницу",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "example": 0,
+                        "description": "Смещение для пагинации",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список песен",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Song"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/random": {
+            "get": {
+                "description": "Возвращает случайные различные песни, подходящие под фильтры. Каждая подходящая песня выбирается с равной вероятностью; с тем же seed выбор повторяется",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Песни"
+                ],
+                "summary": "Случайные песни",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "example": 3,
+                        "description": "Количество песен",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 42,
+                        "description": "Seed для повторяемого выбора",
+                        "name": "seed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Muse\"",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Hysteria\"",
+                        "description": "Название песни",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"It's bugging me, grating me\"",
+                        "description": "Текст песни",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2003-12-15\"",
+                        "description": "Дата релиза",
+                        "name": "release_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"rock\"",
+                        "description": "Жанр (включая поджанры)",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ballad,live\"",
+                        "description": "Теги через запятую",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Режим совпадения тегов",
+                        "name": "tags_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Matthew Bellamy\"",
+                        "description": "Участник работы над песней (исполнитель, автор, продюсер)",
+                        "name": "credited",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 110,
+                        "description": "Минимальный темп",
+                        "name": "bpm_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 130,
+                        "description": "Максимальный темп",
+                        "name": "bpm_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 180000,
+                        "description": "Минимальная длительность, мс",
+                        "name": "duration_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 300000,
+                        "description": "Максимальная длительность, мс",
+                        "name": "duration_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"C#m\"",
+                        "description": "Тональность",
+                        "name": "key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"en\"",
+                        "description": "Язык текста (ISO 639-1)",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "unchecked",
+                            "ok",
+                            "broken"
+                        ],
+                        "type": "string",
+                        "description": "Песни со ссылками в указанном состоянии",
+                        "name": "link_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Исключить песни с ненормативной лексикой",
+                        "name": "no_explicit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Случайные песни",
+                        "schema": {
+                            "$ref": "#/definitions/models.RandomSongsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/tags/add": {
+            "post": {
+                "description": "Привязывает к песне жанры из справочника и произвольные теги (новые теги создаются автоматически)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Жанры и теги"
+                ],
+                "summary": "Добавление жанров и тегов к песне",
+                "parameters": [
+                    {
+                        "description": "Песня, жанры и теги",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TagSongRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Жанры и теги добавлены",
+                        "schema": {
+                            "$ref": "#/definitions/models.DefaultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня или жанр не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/tags/delete": {
+            "delete": {
+                "description": "Отвязывает от песни перечисленные через запятую жанры и теги",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Жанры и теги"
+                ],
+                "summary": "Удаление жанров и тегов у песни",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Hysteria\"",
+                        "description": "Название песни",
+                        "name": "song_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Muse\"",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"alt-rock\"",
+                        "description": "Жанры через запятую",
+                        "name": "genres",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ballad,live\"",
+                        "description": "Теги через запятую",
+                        "name": "tags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Жанры и теги удалены",
+                        "schema": {
+                            "$ref": "#/definitions/models.DefaultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/text": {
+            "get": {
+                "description": "Возвращает текст песни с разбивкой на куплеты и поддержкой пагинации. С параметром lang возвращается перевод, а с parallel=true — пары куплетов оригинала и перевода. С clean=true ненормативная лексика заменяется звёздочками, а с rhymes=true для каждого куплета возвращается схема рифмовки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Песни"
+                ],
+                "summary": "Получение текста песни с пагинацией",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Bohemian Rhapsody\"",
+                        "description": "Название песни",
+                        "name": "song_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Queen\"",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 3,
+                        "example": 2,
+                        "description": "Лимит куплетов на страницу",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "example": 1,
+                        "description": "Смещение для пагинации",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ru\"",
+                        "description": "Код языка перевода",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Вернуть оригинал и перевод парами (требует lang)",
+                        "name": "parallel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Замаскировать ненормативную лексику",
+                        "name": "clean",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Добавить схемы рифмовки куплетов",
+                        "name": "rhymes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Текст песни с пагинацией",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongTextResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/translations": {
+            "get": {
+                "description": "Возвращает все переводы текста песни",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Переводы"
+                ],
+                "summary": "Получение переводов песни",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Hysteria\"",
+                        "description": "Название песни",
+                        "name": "song_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Muse\"",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список переводов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Translation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/translations/add": {
+            "post": {
+                "description": "Добавляет перевод текста песни на указанный язык. Куплеты разделяются так же, как в оригинале",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Переводы"
+                ],
+                "summary": "Добавление перевода песни",
+                "parameters": [
+                    {
+                        "description": "Перевод",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Перевод успешно добавлен",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Перевод на этот язык уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/translations/delete": {
+            "delete": {
+                "description": "Удаляет перевод текста песни на указанный язык",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Переводы"
+                ],
+                "summary": "Удаление перевода песни",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Hysteria\"",
+                        "description": "Название песни",
+                        "name": "song_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Muse\"",
+                        "description": "Название группы",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"ru\"",
+                        "description": "Код языка",
+                        "name": "lang",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Перевод успешно удалён",
+                        "schema": {
+                            "$ref": "#/definitions/models.DefaultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Перевод не найден",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/translations/update": {
+            "put": {
+                "description": "Изменяет текст, автора и статус перевода на указанный язык",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Переводы"
+                ],
+                "summary": "Изменение перевода песни",
+                "parameters": [
+                    {
+                        "description": "Новые данные перевода",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Перевод успешно обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Перевод не найден",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/update": {
+            "put": {
+                "description": "Обновление информации о песне, включая название, группу, текст и метаданные трека",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Песни"
+                ],
+                "summary": "Обновление данных песни",
+                "parameters": [
+                    {
+                        "description": "Обновленные данные песни",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateSongRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Песня успешно обновлена",
+                        "schema": {
+                            "$ref": "#/definitions/models.DefaultResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "ISRC уже занят",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/similar": {
+            "get": {
+                "description": "Возвращает песни, тексты которых ближе всего к тексту заданной (TF-IDF и косинусная близость). Надбавки group_boost и genre_boost повышают оценку песен той же группы или с общим жанром",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Песни"
+                ],
+                "summary": "Похожие песни",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c5f2f9e-2d3b-4a6b-9d43-2f0c8f4f6a11\"",
+                        "description": "UUID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "Количество песен",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 0,
+                        "example": 0.1,
+                        "description": "Надбавка за ту же группу (0–1)",
+                        "name": "group_boost",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 0,
+                        "example": 0.05,
+                        "description": "Надбавка за общий жанр (0–1)",
+                        "name": "genre_boost",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Похожие песни",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SimilarSong"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/songs/{id}/stats": {
+            "get": {
+                "description": "Возвращает количество строк, куплетов и слов, долю уникальных слов, самые частые слова без учёта стоп-слов и долю повторяющихся куплетов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Статистика"
+                ],
+                "summary": "Статистика текста песни",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"8c5f2f9e-2d3b-4a6b-9d43-2f0c8f4f6a11\"",
+                        "description": "UUID песни",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "example": 5,
+                        "description": "Количество самых частых слов",
+                        "name": "top",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статистика текста",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Песня не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Возвращает количество песен и групп, группы с наибольшим числом песен, распределение по годам и десятилетиям релиза, последние добавленные песни, среднюю длину текста и число песен без ссылки, текста или даты релиза. Результат кэшируется на короткое время",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Статистика"
+                ],
+                "summary": "Статистика библиотеки",
+                "responses": {
+                    "200": {
+                        "description": "Статистика библиотеки",
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogueStats"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/groups": {
+            "get": {
+                "description": "Возвращает группы, упорядоченные по убыванию числа песен, с пагинацией",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Статистика"
+                ],
+                "summary": "Количество песен по группам",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "example": 10,
+                        "description": "Лимит групп на страницу",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "example": 0,
+                        "description": "Смещение для пагинации",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Количество песен по группам",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GroupCount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено ограничение частоты запросов",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "База данных недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "Превышено время обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AddGenreRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Название жанра",
+                    "type": "string"
+                },
+                "parent": {
+                    "description": "Название родительского жанра (необязательно)",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AddSongLinkRequest": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "description": "Название группы",
+                    "type": "string"
+                },
+                "region": {
+                    "description": "Регион доступности (необязательно)",
+                    "type": "string"
+                },
+                "song": {
+                    "description": "Название песни",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Ссылка на трек",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AddSongRequest": {
+            "type": "object",
+            "required": [
+                "group",
+                "song"
+            ],
+            "properties": {
+                "bpm": {
+                    "description": "Темп, ударов в минуту",
+                    "type": "integer",
+                    "maximum": 999,
+                    "minimum": 1
+                },
+                "duration_ms": {
+                    "description": "Длительность в миллисекундах",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "explicit": {
+                    "description": "Ненормативное содержание",
+                    "type": "boolean"
+                },
+                "group": {
+                    "description": "Название группы",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "isrc": {
+                    "description": "Международный код записи",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Тональность, например C#m",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "Язык текста; если не указан, определяется автоматически",
+                    "type": "string"
+                },
+                "link": {
+                    "description": "ссылка на песню",
+                    "type": "string"
+                },
+                "release_date": {
+                    "description": "Дата релиза",
+                    "type": "string"
+                },
+                "song": {
+                    "description": "Название песни",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "text": {
+                    "description": "Текст песни",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AddTranslationRequest": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "description": "Название группы",
+                    "type": "string"
+                },
+                "lang": {
+                    "description": "Код языка перевода",
+                    "type": "string"
+                },
+                "song": {
+                    "description": "Название песни",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Статус перевода (по умолчанию draft)",
+                    "type": "string"
+                },
+                "text": {
+                    "description": "Переведённый текст",
+                    "type": "string"
+                },
+                "translator": {
+                    "description": "Автор перевода",
+                    "type": "string"
+                }
+            }
+        },
+        "models.BrokenLink": {
+            "type": "object",
+            "properties": {
+                "external_id": {
+                    "description": "Идентификатор трека у провайдера",
+                    "type": "string",
+                    "example": "3dm_5qWWDV8"
+                },
+                "failure_streak": {
+                    "description": "Число неудачных проверок подряд",
+                    "type": "integer"
+                },
+                "group_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_checked_at": {
+                    "description": "Время последней проверки",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Провайдер, определяется автоматически",
+                    "type": "string",
+                    "example": "youtube"
+                },
+                "region": {
+                    "description": "Регион доступности (ISO 3166-1 alpha-2)",
+                    "type": "string",
+                    "example": "US"
+                },
+                "song_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Результат проверки: unchecked, ok или broken",
+                    "type": "string",
+                    "example": "ok"
+                },
+                "status_code": {
+                    "description": "HTTP-статус последней проверки",
+                    "type": "integer",
+                    "example": 200
+                },
+                "url": {
+                    "description": "Исходная ссылка",
+                    "type": "string",
+                    "example": "https://youtu.be/3dm_5qWWDV8"
+                }
+            }
+        },
+        "models.CatalogueStats": {
+            "type": "object",
+            "properties": {
+                "avg_text_length": {
+                    "description": "Средняя длина текста в символах",
+                    "type": "number"
+                },
+                "generated_at": {
+                    "description": "Время расчёта статистики",
+                    "type": "string"
+                },
+                "groups": {
+                    "description": "Количество групп",
+                    "type": "integer"
+                },
+                "missing": {
+                    "description": "Количество песен без ссылки, текста или даты релиза",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MissingCounts"
+                        }
+                    ]
+                },
+                "newest": {
+                    "description": "Последние добавленные песни",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecentSong"
+                    }
+                },
+                "songs": {
+                    "description": "Количество песен",
+                    "type": "integer"
+                },
+                "songs_per_decade": {
+                    "description": "Песни по десятилетию релиза",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PeriodCount"
+                    }
+                },
+                "songs_per_year": {
+                    "description": "Песни по году релиза",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PeriodCount"
+                    }
+                },
+                "top_groups": {
+                    "description": "Группы с наибольшим числом песен",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupCount"
+                    }
+                }
+            }
+        },
+        "models.Credit": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Matthew Bellamy"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "primary",
+                        "featured",
+                        "composer",
+                        "lyricist",
+                        "producer"
+                    ],
+                    "example": "composer"
+                }
+            }
+        },
+        "models.DefaultResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Сообщение о результате операции",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "HTTP-статус операции",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DuplicateCandidate": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Время обнаружения",
+                    "type": "string"
+                },
+                "duplicate_group": {
+                    "description": "Группа второй песни",
+                    "type": "string"
+                },
+                "duplicate_id": {
+                    "description": "UUID второй песни",
+                    "type": "string"
+                },
+                "duplicate_song_name": {
+                    "description": "Название второй песни",
+                    "type": "string"
+                },
+                "group": {
+                    "description": "Группа первой песни",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Идентификатор кандидата",
+                    "type": "integer"
+                },
+                "similarity": {
+                    "description": "Близость текстов от 0 до 1",
+                    "type": "number"
+                },
+                "song_id": {
+                    "description": "UUID первой песни",
+                    "type": "string"
+                },
+                "song_name": {
+                    "description": "Название первой песни",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending или dismissed",
+                    "type": "string"
+                }
+            }
+        },
+        "models.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Машинно-читаемый код, например required или invalid_format",
+                    "type": "string"
+                },
+                "field": {
+                    "description": "Имя поля или параметра запроса, например isrc",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Описание ошибки",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Genre": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "Родительский жанр (например, rock для alt-rock)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GroupCount": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "description": "Название группы",
+                    "type": "string"
+                },
+                "songs": {
+                    "description": "Количество песен",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GroupStatsResponse": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "description": "Название группы",
+                    "type": "string"
+                },
+                "lines": {
+                    "description": "Количество непустых строк",
+                    "type": "integer"
+                },
+                "repetition_score": {
+                    "description": "Доля слов в повторяющихся куплетах от 0 до 1",
+                    "type": "number"
+                },
+                "songs": {
+                    "description": "Количество песен",
+                    "type": "integer"
+                },
+                "top_words": {
+                    "description": "Самые частые слова без учёта стоп-слов",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WordCount"
+                    }
+                },
+                "unique_word_ratio": {
+                    "description": "Доля различных слов от 0 до 1",
+                    "type": "number"
+                },
+                "unique_words": {
+                    "description": "Количество различных слов",
+                    "type": "integer"
+                },
+                "verses": {
+                    "description": "Количество куплетов",
+                    "type": "integer"
+                },
+                "words": {
+                    "description": "Количество слов",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.HealthCheck": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Причина ошибки",
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "description": "Время проверки в миллисекундах",
+                    "type": "number"
+                },
+                "name": {
+                    "description": "Название зависимости",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "ok или fail",
+                    "type": "string"
+                }
+            }
+        },
+        "models.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "description": "Результаты отдельных проверок",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HealthCheck"
+                    }
+                },
+                "status": {
+                    "description": "ok, если все проверки прошли, иначе fail",
+                    "type": "string"
+                }
+            }
+        },
+        "models.MergeSongsRequest": {
+            "type": "object",
+            "properties": {
+                "duplicate_id": {
+                    "description": "UUID песни, которая удаляется",
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "Поля, значения которых берутся у дубликата",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "survivor_id": {
+                    "description": "UUID песни, которая остаётся",
+                    "type": "string"
+                }
+            }
+        },
+        "models.MissingCounts": {
+            "type": "object",
+            "properties": {
+                "link": {
+                    "description": "Нет ни ссылки, ни ссылок на стриминговые сервисы",
+                    "type": "integer"
+                },
+                "release_date": {
+                    "description": "Не указана дата релиза",
+                    "type": "integer"
+                },
+                "text": {
+                    "description": "Пустой текст",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PeriodCount": {
+            "type": "object",
+            "properties": {
+                "period": {
+                    "description": "Год или первый год десятилетия",
+                    "type": "integer"
+                },
+                "songs": {
+                    "description": "Количество песен",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Машинно-читаемый код ошибки",
+                    "type": "string"
+                },
+                "detail": {
+                    "description": "Описание конкретной ошибки",
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "Ошибки отдельных полей запроса",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldError"
+                    }
+                },
+                "instance": {
+                    "description": "Путь запроса",
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "Идентификатор запроса для поиска в журнале",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "HTTP-статус",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "Краткое описание HTTP-статуса",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "URI типа ошибки, например urn:song-library:problem:song_not_found",
+                    "type": "string"
+                }
+            }
+        },
+        "models.RandomSongsResponse": {
+            "type": "object",
+            "properties": {
+                "seed": {
+                    "description": "Seed, с которым выбор можно повторить",
+                    "type": "integer"
+                },
+                "songs": {
+                    "description": "Выбранные песни",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Song"
                     }
+                },
+                "total": {
+                    "description": "Количество песен, подходящих под фильтры",
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AddSongRequest": {
+        },
+        "models.RecentSong": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "description": "Время добавления",
+                    "type": "string"
+                },
                 "group": {
                     "description": "Название группы",
                     "type": "string"
                 },
-                "link": {
-                    "description": "ссылка на песню",
+                "id": {
+                    "description": "UUID песни",
                     "type": "string"
                 },
-                "release_date": {
-                    "description": "Дата релиза",
+                "song_name": {
+                    "description": "Название песни",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SetCreditsRequest": {
+            "type": "object",
+            "properties": {
+                "credits": {
+                    "description": "Полный список участников с ролями",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Credit"
+                    }
+                },
+                "group": {
+                    "description": "Название группы",
                     "type": "string"
                 },
                 "song": {
                     "description": "Название песни",
                     "type": "string"
-                },
-                "text": {
-                    "description": "Текст песни",
-                    "type": "string"
                 }
             }
         },
-        "models.DefaultResponse": {
+        "models.SimilarSong": {
             "type": "object",
             "properties": {
-                "message": {
-                    "description": "Сообщение о результате операции",
+                "group": {
+                    "description": "Название группы",
                     "type": "string"
                 },
-                "status": {
-                    "description": "HTTP-статус операции",
-                    "type": "integer"
+                "id": {
+                    "description": "UUID песни",
+                    "type": "string"
+                },
+                "score": {
+                    "description": "Итоговая оценка с учётом надбавок за группу и жанр",
+                    "type": "number"
+                },
+                "similarity": {
+                    "description": "Косинусная близость текстов от 0 до 1",
+                    "type": "number"
+                },
+                "song_name": {
+                    "description": "Название песни",
+                    "type": "string"
                 }
             }
         },
         "models.Song": {
             "type": "object",
             "properties": {
+                "bpm": {
+                    "description": "Темп, ударов в минуту",
+                    "type": "integer"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "duration_ms": {
+                    "description": "Длительность в миллисекундах",
+                    "type": "integer"
+                },
+                "explicit": {
+                    "description": "Ненормативное содержание",
+                    "type": "boolean"
+                },
+                "explicit_lines": {
+                    "description": "Номера строк (с единицы) с ненормативной лексикой",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "group_name": {
                     "type": "string"
                 },
@@ -437,6 +3179,26 @@ const docTemplate = `{
                     "description": "UUID",
                     "type": "string"
                 },
+                "isrc": {
+                    "description": "Международный код записи, например USRC17607839",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Тональность, например C#m",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "Язык текста (ISO 639-1)",
+                    "type": "string"
+                },
+                "language_confidence": {
+                    "description": "Уверенность определения языка от 0 до 1",
+                    "type": "number"
+                },
+                "language_manual": {
+                    "description": "Язык задан вручную, а не определён автоматически",
+                    "type": "boolean"
+                },
                 "link": {
                     "type": "string"
                 },
@@ -454,20 +3216,180 @@ const docTemplate = `{
         "models.SongDetail": {
             "type": "object",
             "properties": {
+                "bpm": {
+                    "description": "Темп, ударов в минуту",
+                    "type": "integer"
+                },
+                "credits": {
+                    "description": "Участники работы над песней",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Credit"
+                    }
+                },
+                "duration_ms": {
+                    "description": "Длительность в миллисекундах",
+                    "type": "integer"
+                },
+                "explicit": {
+                    "description": "Ненормативное содержание",
+                    "type": "boolean"
+                },
+                "explicit_lines": {
+                    "description": "Номера строк с ненормативной лексикой",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "genres": {
+                    "description": "Жанры песни",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "isrc": {
+                    "description": "Международный код записи",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Тональность",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "Язык текста",
+                    "type": "string"
+                },
+                "language_confidence": {
+                    "description": "Уверенность определения языка",
+                    "type": "number"
+                },
                 "link": {
                     "description": "Ссылка на песню (например, на YouTube)",
                     "type": "string"
                 },
+                "links": {
+                    "description": "Ссылки на песню, сгруппированные по провайдеру",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/models.SongLink"
+                        }
+                    }
+                },
                 "release_date": {
                     "description": "Дата релиза",
                     "type": "string"
                 },
+                "tags": {
+                    "description": "Теги песни",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "text": {
                     "description": "Текст песни",
                     "type": "string"
                 }
             }
         },
+        "models.SongLink": {
+            "type": "object",
+            "properties": {
+                "external_id": {
+                    "description": "Идентификатор трека у провайдера",
+                    "type": "string",
+                    "example": "3dm_5qWWDV8"
+                },
+                "failure_streak": {
+                    "description": "Число неудачных проверок подряд",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_checked_at": {
+                    "description": "Время последней проверки",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Провайдер, определяется автоматически",
+                    "type": "string",
+                    "example": "youtube"
+                },
+                "region": {
+                    "description": "Регион доступности (ISO 3166-1 alpha-2)",
+                    "type": "string",
+                    "example": "US"
+                },
+                "status": {
+                    "description": "Результат проверки: unchecked, ok или broken",
+                    "type": "string",
+                    "example": "ok"
+                },
+                "status_code": {
+                    "description": "HTTP-статус последней проверки",
+                    "type": "integer",
+                    "example": 200
+                },
+                "url": {
+                    "description": "Исходная ссылка",
+                    "type": "string",
+                    "example": "https://youtu.be/3dm_5qWWDV8"
+                }
+            }
+        },
+        "models.SongStatsResponse": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "description": "Название группы",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "UUID песни",
+                    "type": "string"
+                },
+                "lines": {
+                    "description": "Количество непустых строк",
+                    "type": "integer"
+                },
+                "repetition_score": {
+                    "description": "Доля слов в повторяющихся куплетах от 0 до 1",
+                    "type": "number"
+                },
+                "song_name": {
+                    "description": "Название песни",
+                    "type": "string"
+                },
+                "top_words": {
+                    "description": "Самые частые слова без учёта стоп-слов",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WordCount"
+                    }
+                },
+                "unique_word_ratio": {
+                    "description": "Доля различных слов от 0 до 1",
+                    "type": "number"
+                },
+                "unique_words": {
+                    "description": "Количество различных слов",
+                    "type": "integer"
+                },
+                "verses": {
+                    "description": "Количество куплетов",
+                    "type": "integer"
+                },
+                "words": {
+                    "description": "Количество слов",
+                    "type": "integer"
+                }
+            }
+        },
         "models.SongTextResponse": {
             "type": "object",
             "properties": {
@@ -475,6 +3397,10 @@ const docTemplate = `{
                     "description": "Название группы",
                     "type": "string"
                 },
+                "lang": {
+                    "description": "Язык перевода, если запрошен",
+                    "type": "string"
+                },
                 "limit": {
                     "description": "Количество куплетов на страницу",
                     "type": "integer"
@@ -483,6 +3409,20 @@ const docTemplate = `{
                     "description": "Смещение",
                     "type": "integer"
                 },
+                "pairs": {
+                    "description": "Пары куплетов оригинала и перевода в режиме parallel",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VersePair"
+                    }
+                },
+                "rhymes": {
+                    "description": "Схемы рифмовки куплетов (AABB, ABAB), если запрошены",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "song_name": {
                     "description": "Название песни",
                     "type": "string"
@@ -500,11 +3440,105 @@ const docTemplate = `{
                 }
             }
         },
+        "models.TagSongRequest": {
+            "type": "object",
+            "properties": {
+                "genres": {
+                    "description": "Жанры из справочника",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "group": {
+                    "description": "Название группы",
+                    "type": "string"
+                },
+                "song": {
+                    "description": "Название песни",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Произвольные теги",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.Translation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lang": {
+                    "description": "Код языка (ISO 639-1, например ru или en)",
+                    "type": "string",
+                    "example": "ru"
+                },
+                "status": {
+                    "description": "Статус: draft, review или published",
+                    "type": "string",
+                    "example": "published"
+                },
+                "text": {
+                    "description": "Переведённый текст",
+                    "type": "string"
+                },
+                "translator": {
+                    "description": "Автор перевода",
+                    "type": "string",
+                    "example": "Иван"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UpdateSongRequest": {
             "type": "object",
+            "required": [
+                "new_group",
+                "new_song_name",
+                "old_group",
+                "old_song_name"
+            ],
             "properties": {
+                "new_bpm": {
+                    "description": "Темп, ударов в минуту",
+                    "type": "integer",
+                    "maximum": 999,
+                    "minimum": 1
+                },
+                "new_duration_ms": {
+                    "description": "Длительность в миллисекундах",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "new_explicit": {
+                    "description": "Ненормативное содержание",
+                    "type": "boolean"
+                },
                 "new_group": {
                     "description": "Новое название группы",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "new_isrc": {
+                    "description": "Международный код записи",
+                    "type": "string"
+                },
+                "new_key": {
+                    "description": "Тональность, например C#m",
+                    "type": "string"
+                },
+                "new_language": {
+                    "description": "Язык текста; если не указан, определяется автоматически",
                     "type": "string"
                 },
                 "new_link": {
@@ -517,7 +3551,8 @@ const docTemplate = `{
                 },
                 "new_song_name": {
                     "description": "Новое название песни",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "new_text": {
                     "description": "Новый текст песни",
@@ -525,10 +3560,67 @@ const docTemplate = `{
                 },
                 "old_group": {
                     "description": "Старое название группы",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "old_song_name": {
                     "description": "Название песни, которую нужно обновить",
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "models.UpdateTranslationRequest": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "description": "Название группы",
+                    "type": "string"
+                },
+                "lang": {
+                    "description": "Код языка перевода",
+                    "type": "string"
+                },
+                "new_status": {
+                    "description": "Новый статус перевода",
+                    "type": "string"
+                },
+                "new_text": {
+                    "description": "Новый текст перевода",
+                    "type": "string"
+                },
+                "new_translator": {
+                    "description": "Автор перевода",
+                    "type": "string"
+                },
+                "song": {
+                    "description": "Название песни",
+                    "type": "string"
+                }
+            }
+        },
+        "models.VersePair": {
+            "type": "object",
+            "properties": {
+                "original": {
+                    "description": "Куплет оригинала",
+                    "type": "string"
+                },
+                "translation": {
+                    "description": "Соответствующий куплет перевода",
+                    "type": "string"
+                }
+            }
+        },
+        "models.WordCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Количество вхождений",
+                    "type": "integer"
+                },
+                "word": {
+                    "description": "Слово в нижнем регистре",
                     "type": "string"
                 }
             }
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"song-libary/models"
+	"song-libary/service"
+)
+
+type CreditHandler struct {
+	Service *service.CreditService
+	Logger  *slog.Logger
+}
+
+func NewCreditHandler(service *service.CreditService, logger *slog.Logger) *CreditHandler {
+	return &CreditHandler{Service: service, Logger: logger}
+}
+
+// GetCreditsHandler возвращает участников работы над песней
+// @Summary Получение участников работы над песней
+// @Description Возвращает исполнителей, приглашённых артистов, композиторов, авторов текста и продюсеров песни
+// @Tags Участники
+// @Produce json
+// @Param song_name query string true "Название песни" example("Hysteria")
+// @Param group query string true "Название группы" example("Muse")
+// @Success 200 {array} models.Credit "Список участников"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/credits [get]
+func (h *CreditHandler) GetCreditsHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to fetch song credits")
+
+	if !requireParams(h.Logger, w, r, "song_name", "group") {
+		return
+	}
+	songName := r.URL.Query().Get("song_name")
+	group := r.URL.Query().Get("group")
+
+	credits, err := h.Service.GetCredits(r.Context(), songName, group)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to fetch song credits")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, credits)
+}
+
+// SetCreditsHandler заменяет список участников работы над песней
+// @Summary Изменение участников работы над песней
+// @Description Полностью заменяет список участников песни; порядок в запросе сохраняется
+// @Tags Участники
+// @Accept json
+// @Produce json
+// @Param request body models.SetCreditsRequest true "Песня и новый список участников"
+// @Success 200 {object} models.DefaultResponse "Участники успешно обновлены"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/credits/update [put]
+func (h *CreditHandler) SetCreditsHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to update song credits")
+
+	var request models.SetCreditsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeInvalidBody(h.Logger, w, r, err)
+		return
+	}
+
+	if err := h.Service.SetCredits(r.Context(), request); err != nil {
+		writeError(h.Logger, w, r, err, "Failed to update song credits")
+		return
+	}
+
+	response := models.DefaultResponse{
+		Message: "Song credits updated successfully",
+		Status:  http.StatusOK,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"song-libary/service"
+)
+
+// GetSongsOnThisDayHandler возвращает песни, вышедшие в этот день в разные годы
+// @Summary Песни, вышедшие в этот день
+// @Description Возвращает песни, дата релиза которых совпадает с date по месяцу и дню, от старых к новым. Без date используется сегодняшний день
+// @Tags Песни
+// @Produce json
+// @Param date query string false "Дата в формате YYYY-MM-DD или MM-DD" example("07-16")
+// @Param limit query int false "Лимит песен на страницу" default(10) minimum(1) maximum(100) example(5)
+// @Param offset query int false "Смещение для пагинации" default(0) minimum(0) example(0)
+// @Success 200 {array} models.Song "Список песен"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/on-this-day [get]
+func (h *SongHandler) GetSongsOnThisDayHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to fetch songs released on this day")
+
+	query := newQueryParser(r)
+	page := query.Page(10) // Значение по умолчанию
+	if !validateQuery(h.Logger, w, r, query, page) {
+		return
+	}
+
+	songs, err := h.Service.GetSongsOnThisDay(r.Context(), query.String("date"), page.Limit, page.Offset)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to fetch songs released on this day")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, songs)
+}
+
+// GetRandomSongsHandler возвращает случайные песни
+// @Summary Случайные песни
+// @Description Возвращает случайные различные песни, подходящие под фильтры. Каждая подходящая песня выбирается с равной вероятностью; с тем же seed выбор повторяется
+// @Tags Песни
+// @Produce json
+// @Param count query int false "Количество песен" default(1) example(3)
+// @Param seed query int false "Seed для повторяемого выбора" example(42)
+// @Param group query string false "Название группы" example("Muse")
+// @Param song query string false "Название песни" example("Hysteria")
+// @Param text query string false "Текст песни" example("It's bugging me, grating me")
+// @Param release_date query string false "Дата релиза" example("2003-12-15")
+// @Param genre query string false "Жанр (включая поджанры)" example("rock")
+// @Param tags query string false "Теги через запятую" example("ballad,live")
+// @Param tags_match query string false "Режим совпадения тегов" Enums(any, all) default(any)
+// @Param credited query string false "Участник работы над песней (исполнитель, автор, продюсер)" example("Matthew Bellamy")
+// @Param bpm_min query int false "Минимальный темп" example(110)
+// @Param bpm_max query int false "Максимальный темп" example(130)
+// @Param duration_min query int false "Минимальная длительность, мс" example(180000)
+// @Param duration_max query int false "Максимальная длительность, мс" example(300000)
+// @Param key query string false "Тональность" example("C#m")
+// @Param language query string false "Язык текста (ISO 639-1)" example("en")
+// @Param link_status query string false "Песни со ссылками в указанном состоянии" Enums(unchecked, ok, broken)
+// @Param no_explicit query bool false "Исключить песни с ненормативной лексикой" default(false)
+// @Success 200 {object} models.RandomSongsResponse "Случайные песни"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/random [get]
+func (h *SongHandler) GetRandomSongsHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to pick random songs")
+
+	query := newQueryParser(r)
+	params := parseFilterParams(query)
+	count := service.DefaultRandomCount
+	if value := query.Int("count"); value != nil {
+		count = *value
+	}
+	seed := query.Int64("seed")
+	// Пагинация к случайной выборке не относится, поэтому проверяются только фильтры
+	filter := params
+	filter.Limit = 1
+	if !validateQuery(h.Logger, w, r, query, filter) {
+		return
+	}
+
+	response, err := h.Service.GetRandomSongs(r.Context(), params, count, seed)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to pick random songs")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
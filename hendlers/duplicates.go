@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"song-libary/models"
+	"song-libary/service"
+	"strconv"
+)
+
+type DuplicateHandler struct {
+	Service *service.DuplicateService
+	Logger  *slog.Logger
+}
+
+func NewDuplicateHandler(service *service.DuplicateService, logger *slog.Logger) *DuplicateHandler {
+	return &DuplicateHandler{Service: service, Logger: logger}
+}
+
+// GetDuplicatesHandler возвращает кандидатов в дубликаты
+// @Summary Кандидаты в дубликаты
+// @Description Возвращает пары песен с одинаковыми нормализованными названием и группой и близкими текстами, найденные командой dupscan, начиная с самых похожих
+// @Tags Дубликаты
+// @Produce json
+// @Param status query string false "Статус проверки" Enums(pending, dismissed) default(pending)
+// @Param limit query int false "Лимит пар на страницу" default(20) minimum(1) maximum(100) example(10)
+// @Param offset query int false "Смещение для пагинации" default(0) minimum(0) example(0)
+// @Success 200 {array} models.DuplicateCandidate "Кандидаты в дубликаты"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /duplicates [get]
+func (h *DuplicateHandler) GetDuplicatesHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to fetch duplicate candidates")
+
+	query := newQueryParser(r)
+	page := query.Page(20) // Значение по умолчанию
+	if !validateQuery(h.Logger, w, r, query, page) {
+		return
+	}
+
+	candidates, err := h.Service.GetCandidates(r.Context(), query.String("status"), page.Limit, page.Offset)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to fetch duplicate candidates")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, candidates)
+}
+
+// DismissDuplicateHandler отклоняет кандидата в дубликаты
+// @Summary Отклонение кандидата в дубликаты
+// @Description Отмечает пару как разные песни; повторное сканирование не вернёт её на проверку
+// @Tags Дубликаты
+// @Produce json
+// @Param id path int true "Идентификатор кандидата" example(1)
+// @Success 200 {object} models.DefaultResponse "Кандидат отклонён"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Кандидат не найден"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /duplicates/{id}/dismiss [post]
+func (h *DuplicateHandler) DismissDuplicateHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to dismiss duplicate candidate")
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeInvalidParam(h.Logger, w, r, "id", err)
+		return
+	}
+
+	if err := h.Service.DismissCandidate(r.Context(), id); err != nil {
+		writeError(h.Logger, w, r, err, "Failed to dismiss duplicate candidate")
+		return
+	}
+
+	response := models.DefaultResponse{
+		Message: "Duplicate candidate dismissed",
+		Status:  http.StatusOK,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// MergeSongsHandler объединяет две песни
+// @Summary Слияние песен
+// @Description Переносит жанры, теги, участников, ссылки и переводы дубликата на оставляемую песню и удаляет дубликат. Поля из fields берутся у дубликата, остальные остаются у оставляемой песни
+// @Tags Дубликаты
+// @Accept json
+// @Produce json
+// @Param request body models.MergeSongsRequest true "Песни и поля, которые берутся у дубликата"
+// @Success 200 {object} models.Song "Песня после слияния"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 409 {object} models.Problem "ISRC уже занят"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/merge [post]
+func (h *DuplicateHandler) MergeSongsHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to merge songs")
+
+	var request models.MergeSongsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeInvalidBody(h.Logger, w, r, err)
+		return
+	}
+
+	song, err := h.Service.MergeSongs(r.Context(), request)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to merge songs")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, song)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"song-libary/logging"
+	"song-libary/models"
+	"song-libary/service"
+	"song-libary/validation"
+)
+
+// problemTypePrefix — префикс URI типа ошибки; к нему добавляется машинно-читаемый код
+const problemTypePrefix = "urn:song-library:problem:"
+
+// kindStatus сопоставляет категории ошибок сервиса HTTP-статусам
+var kindStatus = map[service.Kind]int{
+	service.KindValidation:  http.StatusBadRequest,
+	service.KindNotFound:    http.StatusNotFound,
+	service.KindConflict:    http.StatusConflict,
+	service.KindUnavailable: http.StatusServiceUnavailable,
+	service.KindTimeout:     http.StatusGatewayTimeout,
+	service.KindInternal:    http.StatusInternalServerError,
+}
+
+// writeError отправляет ответ application/problem+json для ошибки сервиса err. Статус выбирается
+// по категории ошибки. Ошибки клиента описываются текстом ошибки вместе с уточнениями, которыми
+// она обёрнута, ошибки сервера — сообщением message: подробности попадают только в журнал
+func writeError(logger *slog.Logger, w http.ResponseWriter, r *http.Request, err error, message string) {
+	e := service.AsError(r.Context(), err)
+	status := kindStatus[e.Kind]
+
+	detail := err.Error()
+	if status >= http.StatusInternalServerError {
+		logger.ErrorContext(r.Context(), message, "error", err, "code", e.Code)
+		detail = message
+	} else {
+		logger.InfoContext(r.Context(), "Request rejected", "error", err, "code", e.Code)
+	}
+
+	problem := newProblem(r, status, e.Code, detail)
+	problem.Errors = e.Fields
+	writeProblem(w, problem)
+}
+
+// NotFound отвечает 404 на запросы к незарегистрированным путям
+func NotFound(logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.InfoContext(r.Context(), "Route not found", "path", r.URL.Path)
+		writeProblem(w, newProblem(r, http.StatusNotFound, "route_not_found", "Route not found"))
+	})
+}
+
+// MethodNotAllowed отвечает 405 на запросы неподдерживаемым методом; заголовок Allow
+// устанавливает маршрутизатор
+func MethodNotAllowed(logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.InfoContext(r.Context(), "Method not allowed", "method", r.Method, "allow", w.Header().Get("Allow"))
+		writeProblem(w, newProblem(r, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed"))
+	})
+}
+
+// writeInvalidBody отправляет ответ 400 для тела запроса, которое не удалось разобрать.
+// Если значение поля имеет неверный тип, ошибка относится к этому полю
+func writeInvalidBody(logger *slog.Logger, w http.ResponseWriter, r *http.Request, err error) {
+	logger.InfoContext(r.Context(), "Failed to decode request body", "error", err)
+	problem := newProblem(r, http.StatusBadRequest, "invalid_body", "Invalid request body")
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		problem.Errors = []models.FieldError{{
+			Field:   typeErr.Field,
+			Code:    "invalid_type",
+			Message: "must be " + typeErr.Type.String(),
+		}}
+	}
+	writeProblem(w, problem)
+}
+
+// requireParams проверяет, что обязательные параметры запроса names заданы; иначе отправляет
+// ответ 400 со списком всех пропущенных параметров и возвращает false
+func requireParams(logger *slog.Logger, w http.ResponseWriter, r *http.Request, names ...string) bool {
+	var fields []models.FieldError
+	for _, name := range names {
+		if r.URL.Query().Get(name) == "" {
+			fields = append(fields, models.FieldError{Field: name, Code: "required", Message: "is required"})
+		}
+	}
+	if len(fields) == 0 {
+		return true
+	}
+	writeError(logger, w, r, service.ValidationError(fields...), "")
+	return false
+}
+
+// validateRequest проверяет запрос по правилам тегов validate; при нарушениях отправляет ответ 400
+// со всеми ошибками полей и возвращает false
+func validateRequest(logger *slog.Logger, w http.ResponseWriter, r *http.Request, request any) bool {
+	fields := validation.Struct(request)
+	if len(fields) == 0 {
+		return true
+	}
+	writeError(logger, w, r, service.ValidationError(fields...), "")
+	return false
+}
+
+// validateQuery отправляет ответ 400 со всеми ошибками разбора параметров query и нарушениями
+// правил тегов validate запроса request (nil — без проверки правил) и возвращает false, если они есть
+func validateQuery(logger *slog.Logger, w http.ResponseWriter, r *http.Request, query *queryParser, request any) bool {
+	fields := query.errors
+	if request != nil {
+		fields = append(fields, validation.Struct(request)...)
+	}
+	if len(fields) == 0 {
+		return true
+	}
+	writeError(logger, w, r, service.ValidationError(fields...), "")
+	return false
+}
+
+// writeInvalidParam отправляет ответ 400 для некорректного параметра запроса
+func writeInvalidParam(logger *slog.Logger, w http.ResponseWriter, r *http.Request, name string, err error) {
+	logger.DebugContext(r.Context(), "Invalid query parameter", "param", name, "error", err)
+	writeError(logger, w, r, service.ValidationError(invalidParam(name)), "")
+}
+
+// newProblem создает описание ошибки для запроса r
+func newProblem(r *http.Request, status int, code, detail string) models.Problem {
+	return models.Problem{
+		Type:      problemTypePrefix + code,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		Code:      code,
+		RequestID: logging.RequestID(r.Context()),
+	}
+}
+
+// writeProblem отправляет ответ в формате application/problem+json
+func writeProblem(w http.ResponseWriter, problem models.Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		slog.Error("Failed to encode problem", "error", err)
+	}
+}
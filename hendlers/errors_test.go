@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"song-libary/models"
+	"song-libary/service"
+	"testing"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// decodeProblem проверяет тип содержимого ответа и разбирает его тело
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) models.Problem {
+	t.Helper()
+	if got := w.Header().Get("Content-Type"); got != "application/problem+json" {
+		t.Fatalf("Content-Type = %q, want application/problem+json", got)
+	}
+	var problem models.Problem
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	if problem.Status != w.Code {
+		t.Errorf("problem status = %d, response status %d", problem.Status, w.Code)
+	}
+	return problem
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		ctx        func() (context.Context, context.CancelFunc)
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{name: "not found", err: service.ErrSongNotFound, wantStatus: http.StatusNotFound, wantCode: "song_not_found", wantDetail: "song not found"},
+		{name: "conflict", err: service.ErrDuplicateISRC, wantStatus: http.StatusConflict, wantCode: "duplicate_isrc", wantDetail: "isrc already belongs to another song"},
+		{name: "validation", err: service.ErrInvalidLink, wantStatus: http.StatusBadRequest, wantCode: "invalid_link", wantDetail: "invalid link"},
+		{
+			name:       "wrapped validation keeps detail",
+			err:        fmt.Errorf("%w: malformed Spotify track ID", service.ErrInvalidLink),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_link",
+			wantDetail: "invalid link: malformed Spotify track ID",
+		},
+		{name: "unavailable", err: service.ErrUnavailable, wantStatus: http.StatusServiceUnavailable, wantCode: "database_unavailable", wantDetail: "Failed to fetch"},
+		{name: "deadline", err: context.DeadlineExceeded, wantStatus: http.StatusGatewayTimeout, wantCode: "timeout", wantDetail: "Failed to fetch"},
+		{name: "lost connection", err: sql.ErrConnDone, wantStatus: http.StatusServiceUnavailable, wantCode: "database_unavailable", wantDetail: "Failed to fetch"},
+		{
+			name: "canceled request",
+			err:  errors.New("pq: canceling statement due to user request"),
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   "database_unavailable",
+			wantDetail: "Failed to fetch",
+		},
+		{name: "internal hides details", err: errors.New("pq: relation songs does not exist"), wantStatus: http.StatusInternalServerError, wantCode: "internal_error", wantDetail: "Failed to fetch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/songs/info", nil)
+			if tt.ctx != nil {
+				ctx, cancel := tt.ctx()
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			w := httptest.NewRecorder()
+			writeError(discardLogger, w, r, tt.err, "Failed to fetch")
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			problem := decodeProblem(t, w)
+			want := models.Problem{
+				Type:     "urn:song-library:problem:" + tt.wantCode,
+				Title:    http.StatusText(tt.wantStatus),
+				Status:   tt.wantStatus,
+				Detail:   tt.wantDetail,
+				Instance: "/songs/info",
+				Code:     tt.wantCode,
+			}
+			if problem.Type != want.Type || problem.Title != want.Title || problem.Detail != want.Detail ||
+				problem.Instance != want.Instance || problem.Code != want.Code {
+				t.Errorf("problem = %+v, want %+v", problem, want)
+			}
+		})
+	}
+}
+
+func TestWriteErrorFields(t *testing.T) {
+	fields := []models.FieldError{
+		{Field: "group", Code: "required", Message: "is required"},
+		{Field: "link", Code: "invalid_url", Message: "must be an absolute http(s) URL"},
+	}
+	w := httptest.NewRecorder()
+	writeError(discardLogger, w, httptest.NewRequest(http.MethodPost, "/songs/add", nil), service.ValidationError(fields...), "")
+
+	problem := decodeProblem(t, w)
+	if w.Code != http.StatusBadRequest || problem.Code != "validation_failed" {
+		t.Fatalf("status = %d, code = %q, want 400 validation_failed", w.Code, problem.Code)
+	}
+	if len(problem.Errors) != len(fields) || problem.Errors[0] != fields[0] || problem.Errors[1] != fields[1] {
+		t.Errorf("errors = %+v, want %+v", problem.Errors, fields)
+	}
+}
+
+func TestRouteProblems(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "not found", handler: NotFound(discardLogger), wantStatus: http.StatusNotFound, wantCode: "route_not_found"},
+		{name: "method not allowed", handler: MethodNotAllowed(discardLogger), wantStatus: http.StatusMethodNotAllowed, wantCode: "method_not_allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/nowhere", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if problem := decodeProblem(t, w); problem.Code != tt.wantCode || problem.Instance != "/nowhere" {
+				t.Errorf("problem = %+v, want code %q for /nowhere", problem, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWriteInvalidBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantFields []models.FieldError
+	}{
+		{name: "malformed json", body: `{"group":`},
+		{
+			name:       "wrong field type",
+			body:       `{"group": "Queen", "song": "Bohemian Rhapsody", "bpm": "fast"}`,
+			wantFields: []models.FieldError{{Field: "bpm", Code: "invalid_type", Message: "must be int"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request models.AddSongRequest
+			err := json.Unmarshal([]byte(tt.body), &request)
+			if err == nil {
+				t.Fatal("body decoded without error")
+			}
+
+			w := httptest.NewRecorder()
+			writeInvalidBody(discardLogger, w, httptest.NewRequest(http.MethodPost, "/songs/add", nil), err)
+
+			problem := decodeProblem(t, w)
+			if w.Code != http.StatusBadRequest || problem.Code != "invalid_body" {
+				t.Fatalf("status = %d, code = %q, want 400 invalid_body", w.Code, problem.Code)
+			}
+			if len(problem.Errors) != len(tt.wantFields) || (len(tt.wantFields) > 0 && problem.Errors[0] != tt.wantFields[0]) {
+				t.Errorf("errors = %+v, want %+v", problem.Errors, tt.wantFields)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"song-libary/models"
+	"song-libary/service"
+)
+
+type HealthHandler struct {
+	Service *service.HealthService
+	Logger  *slog.Logger
+}
+
+func NewHealthHandler(service *service.HealthService, logger *slog.Logger) *HealthHandler {
+	return &HealthHandler{Service: service, Logger: logger}
+}
+
+// LivenessHandler сообщает, что процесс жив
+// @Summary Проверка живости
+// @Description Возвращает 200, пока процесс обслуживает запросы. Зависимости не проверяются
+// @Tags Состояние
+// @Produce json
+// @Success 200 {object} models.HealthResponse "Процесс работает"
+// @Router /healthz [get]
+func (h *HealthHandler) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSONResponse(w, http.StatusOK, h.Service.Liveness())
+}
+
+// ReadinessHandler проверяет готовность сервиса принимать запросы
+// @Summary Проверка готовности
+// @Description Проверяет подключение к базе данных, отсутствие непримененных миграций и другие зависимости. Возвращает результат и время каждой проверки; во время остановки сервера всегда возвращает 503
+// @Tags Состояние
+// @Produce json
+// @Success 200 {object} models.HealthResponse "Сервис готов"
+// @Failure 503 {object} models.HealthResponse "Сервис не готов"
+// @Router /readyz [get]
+func (h *HealthHandler) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	response := h.Service.Readiness(r.Context())
+	status := http.StatusOK
+	if response.Status != models.HealthStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSONResponse(w, status, response)
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"song-libary/service"
+	"testing"
+)
+
+func TestReadinessHandlerStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		shuttingDown bool
+		want         int
+	}{
+		{name: "ready", want: http.StatusOK},
+		{name: "shutting down", shuttingDown: true, want: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := service.NewHealthService(discardLogger, service.HealthCheck{
+				Name:  "database",
+				Check: func(context.Context) error { return nil },
+			})
+			if tt.shuttingDown {
+				health.SetShuttingDown()
+			}
+			h := NewHealthHandler(health, discardLogger)
+
+			w := httptest.NewRecorder()
+			h.ReadinessHandler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"song-libary/models"
+	"song-libary/service"
+	"strconv"
+)
+
+type LinkHandler struct {
+	Service *service.LinkService
+	Logger  *slog.Logger
+}
+
+func NewLinkHandler(service *service.LinkService, logger *slog.Logger) *LinkHandler {
+	return &LinkHandler{Service: service, Logger: logger}
+}
+
+// GetLinksHandler возвращает ссылки на песню
+// @Summary Получение ссылок на песню
+// @Description Возвращает ссылки на песню в стриминговых сервисах, сгруппированные по провайдеру
+// @Tags Ссылки
+// @Produce json
+// @Param song_name query string true "Название песни" example("Hysteria")
+// @Param group query string true "Название группы" example("Muse")
+// @Success 200 {object} map[string][]models.SongLink "Ссылки по провайдерам"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/links [get]
+func (h *LinkHandler) GetLinksHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to fetch song links")
+
+	if !requireParams(h.Logger, w, r, "song_name", "group") {
+		return
+	}
+	songName := r.URL.Query().Get("song_name")
+	group := r.URL.Query().Get("group")
+
+	links, err := h.Service.GetLinks(r.Context(), songName, group)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to fetch song links")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, links)
+}
+
+// AddLinkHandler добавляет ссылку на песню
+// @Summary Добавление ссылки на песню
+// @Description Добавляет ссылку на YouTube, Spotify, Apple Music, Bandcamp или другой сервис. Провайдер и идентификатор трека определяются автоматически
+// @Tags Ссылки
+// @Accept json
+// @Produce json
+// @Param request body models.AddSongLinkRequest true "Песня и ссылка"
+// @Success 201 {object} models.SongLink "Ссылка успешно добавлена"
+// @Failure 400 {object} models.Problem "Некорректная ссылка"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 409 {object} models.Problem "Ссылка уже добавлена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/links/add [post]
+func (h *LinkHandler) AddLinkHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to add song link")
+
+	var request models.AddSongLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeInvalidBody(h.Logger, w, r, err)
+		return
+	}
+
+	link, err := h.Service.AddLink(r.Context(), request)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to add song link")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, link)
+}
+
+// DeleteLinkHandler удаляет ссылку на песню
+// @Summary Удаление ссылки на песню
+// @Description Удаляет ссылку по её идентификатору
+// @Tags Ссылки
+// @Produce json
+// @Param id query int true "Идентификатор ссылки" example(1)
+// @Success 200 {object} models.DefaultResponse "Ссылка успешно удалена"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Ссылка не найдена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/links/delete [delete]
+func (h *LinkHandler) DeleteLinkHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to delete song link")
+
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil || id <= 0 {
+		writeInvalidParam(h.Logger, w, r, "id", err)
+		return
+	}
+
+	if err := h.Service.DeleteLink(r.Context(), id); err != nil {
+		writeError(h.Logger, w, r, err, "Failed to delete song link")
+		return
+	}
+
+	response := models.DefaultResponse{
+		Message: "Song link deleted successfully",
+		Status:  http.StatusOK,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// BrokenLinksReportHandler возвращает отчёт о нерабочих ссылках
+// @Summary Отчёт о нерабочих ссылках
+// @Description Возвращает ссылки, которые не отвечают несколько проверок подряд, с HTTP-статусом, временем последней проверки и числом неудач
+// @Tags Ссылки
+// @Produce json
+// @Success 200 {array} models.BrokenLink "Нерабочие ссылки"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/links/report [get]
+func (h *LinkHandler) BrokenLinksReportHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request for broken links report")
+
+	report, err := h.Service.GetBrokenLinks(r.Context())
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to fetch broken links report")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, report)
+}
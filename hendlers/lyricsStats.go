@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"song-libary/service"
+)
+
+// GetSongStatsHandler возвращает статистику текста песни
+// @Summary Статистика текста песни
+// @Description Возвращает количество строк, куплетов и слов, долю уникальных слов, самые частые слова без учёта стоп-слов и долю повторяющихся куплетов
+// @Tags Статистика
+// @Produce json
+// @Param id path string true "UUID песни" example("8c5f2f9e-2d3b-4a6b-9d43-2f0c8f4f6a11")
+// @Param top query int false "Количество самых частых слов" default(10) example(5)
+// @Success 200 {object} models.SongStatsResponse "Статистика текста"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/{id}/stats [get]
+func (h *SongHandler) GetSongStatsHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to fetch song stats")
+
+	top, ok := h.parseTop(w, r)
+	if !ok {
+		return
+	}
+
+	stats, err := h.Service.GetSongStats(r.Context(), r.PathValue("id"), top)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to calculate song stats")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, stats)
+}
+
+// GetGroupStatsHandler возвращает статистику текстов всех песен группы
+// @Summary Статистика текстов группы
+// @Description Возвращает статистику текстов, объединённую по всем песням группы
+// @Tags Статистика
+// @Produce json
+// @Param group path string true "Название группы" example("Muse")
+// @Param top query int false "Количество самых частых слов" default(10) example(5)
+// @Success 200 {object} models.GroupStatsResponse "Статистика текстов группы"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песни группы не найдены"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /groups/{group}/stats [get]
+func (h *SongHandler) GetGroupStatsHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to fetch group stats")
+
+	top, ok := h.parseTop(w, r)
+	if !ok {
+		return
+	}
+
+	stats, err := h.Service.GetGroupStats(r.Context(), r.PathValue("group"), top)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to calculate group stats")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, stats)
+}
+
+// parseTop читает параметр top; при ошибке отправляет ответ 400 и возвращает false.
+// Диапазон значения проверяет сервис
+func (h *SongHandler) parseTop(w http.ResponseWriter, r *http.Request) (int, bool) {
+	query := newQueryParser(r)
+	top := query.Int("top")
+	if !validateQuery(h.Logger, w, r, query, nil) {
+		return 0, false
+	}
+	if top == nil {
+		return service.DefaultTopWords, true
+	}
+	return *top, true
+}
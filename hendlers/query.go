@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"song-libary/models"
+	"strconv"
+)
+
+// queryParser читает необязательные параметры запроса и накапливает ошибки разбора в порядке
+// чтения, чтобы клиент получил все некорректные параметры в одном ответе
+type queryParser struct {
+	query  url.Values
+	errors []models.FieldError
+}
+
+func newQueryParser(r *http.Request) *queryParser {
+	return &queryParser{query: r.URL.Query()}
+}
+
+// String возвращает строковый параметр name; пустая строка — параметр не задан
+func (p *queryParser) String(name string) string {
+	return p.query.Get(name)
+}
+
+// Int возвращает целочисленный параметр name; nil — параметр не задан или некорректен
+func (p *queryParser) Int(name string) *int {
+	return parseParam(p, name, strconv.Atoi)
+}
+
+// Int64 возвращает параметр name типа int64; nil — параметр не задан или некорректен
+func (p *queryParser) Int64(name string) *int64 {
+	return parseParam(p, name, func(raw string) (int64, error) { return strconv.ParseInt(raw, 10, 64) })
+}
+
+// Float возвращает дробный параметр name; nil — параметр не задан или некорректен
+func (p *queryParser) Float(name string) *float64 {
+	return parseParam(p, name, func(raw string) (float64, error) { return strconv.ParseFloat(raw, 64) })
+}
+
+// Page возвращает параметры пагинации limit и offset; defaultLimit используется, если limit не задан
+func (p *queryParser) Page(defaultLimit int) models.PageParams {
+	page := models.PageParams{Limit: defaultLimit}
+	if limit := p.Int("limit"); limit != nil {
+		page.Limit = *limit
+	}
+	if offset := p.Int("offset"); offset != nil {
+		page.Offset = *offset
+	}
+	return page
+}
+
+// parseParam разбирает параметр name функцией parse и запоминает ошибку разбора
+func parseParam[T any](p *queryParser, name string, parse func(string) (T, error)) *T {
+	raw := p.query.Get(name)
+	if raw == "" {
+		return nil
+	}
+	value, err := parse(raw)
+	if err != nil {
+		p.errors = append(p.errors, invalidParam(name))
+		return nil
+	}
+	return &value
+}
+
+// invalidParam описывает параметр запроса name, значение которого не удалось разобрать
+func invalidParam(name string) models.FieldError {
+	return models.FieldError{Field: name, Code: "invalid", Message: "invalid value"}
+}
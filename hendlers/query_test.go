@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"song-libary/models"
+	"song-libary/service"
+	"testing"
+)
+
+func TestGetSongsHandlerReportsAllParamErrors(t *testing.T) {
+	h := &SongHandler{Logger: discardLogger}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string // Поля с ошибками в порядке ответа
+	}{
+		{
+			name:  "parse errors in fixed order",
+			query: "offset=x&duration_max=x&limit=x&bpm_min=x",
+			want:  []string{"bpm_min", "duration_max", "limit", "offset"},
+		},
+		{
+			name:  "parse errors before rule violations",
+			query: "release_date=tomorrow&offset=-1&bpm_max=fast",
+			want:  []string{"bpm_max", "release_date", "offset"},
+		},
+		{
+			name:  "rule violations only",
+			query: "limit=1000&offset=-1",
+			want:  []string{"limit", "offset"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Порядок не должен зависеть от обхода map: повторяем запрос несколько раз
+			for range 10 {
+				w := httptest.NewRecorder()
+				h.GetSongsHandler(w, httptest.NewRequest(http.MethodGet, "/songs?"+tt.query, nil))
+
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("status = %d, want 400", w.Code)
+				}
+				var problem models.Problem
+				if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+					t.Fatalf("decode problem: %v", err)
+				}
+				var got []string
+				for _, field := range problem.Errors {
+					got = append(got, field.Field)
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Fatalf("error fields = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetRandomSongsHandlerValidatesFilter(t *testing.T) {
+	h := &SongHandler{Logger: discardLogger}
+
+	w := httptest.NewRecorder()
+	h.GetRandomSongsHandler(w, httptest.NewRequest(http.MethodGet, "/songs/random?release_date=tomorrow&key=H&count=x", nil))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	var problem models.Problem
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	var got []string
+	for _, field := range problem.Errors {
+		got = append(got, field.Field)
+	}
+	if want := []string{"count", "release_date", "key"}; !slices.Equal(got, want) {
+		t.Errorf("error fields = %v, want %v", got, want)
+	}
+}
+
+func TestStatsHandlersRejectUnparsableTop(t *testing.T) {
+	h := &SongHandler{Logger: discardLogger}
+
+	for _, handler := range []http.HandlerFunc{h.GetSongStatsHandler, h.GetGroupStatsHandler} {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/stats?top=many", nil))
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want 400", w.Code)
+		}
+		var problem models.Problem
+		if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+			t.Fatalf("decode problem: %v", err)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "top" {
+			t.Errorf("errors = %+v, want top", problem.Errors)
+		}
+	}
+}
+
+func TestPaginatedHandlersRejectInvalidParams(t *testing.T) {
+	songs := &SongHandler{Logger: discardLogger}
+	duplicates := &DuplicateHandler{Logger: discardLogger}
+	stats := &StatsHandler{Service: service.NewStatsService(nil, discardLogger), Logger: discardLogger}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		target  string
+		want    []string // Поля с ошибками в порядке ответа
+	}{
+		{name: "on this day unparsable", handler: songs.GetSongsOnThisDayHandler, target: "/songs/on-this-day?limit=x&offset=y", want: []string{"limit", "offset"}},
+		{name: "on this day out of range", handler: songs.GetSongsOnThisDayHandler, target: "/songs/on-this-day?limit=0&offset=-1", want: []string{"limit", "offset"}},
+		{name: "on this day limit too large", handler: songs.GetSongsOnThisDayHandler, target: "/songs/on-this-day?limit=101", want: []string{"limit"}},
+		{name: "duplicates unparsable", handler: duplicates.GetDuplicatesHandler, target: "/duplicates?limit=x&offset=y", want: []string{"limit", "offset"}},
+		{name: "duplicates out of range", handler: duplicates.GetDuplicatesHandler, target: "/duplicates?limit=500&offset=-3", want: []string{"limit", "offset"}},
+		{name: "group counts unparsable", handler: stats.GetGroupCountsHandler, target: "/stats/groups?limit=x&offset=y", want: []string{"limit", "offset"}},
+		{name: "group counts out of range", handler: stats.GetGroupCountsHandler, target: "/stats/groups?limit=0&offset=-1", want: []string{"limit", "offset"}},
+		{name: "group counts limit too large", handler: stats.GetGroupCountsHandler, target: "/stats/groups?limit=101", want: []string{"limit"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.handler(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", w.Code)
+			}
+			var problem models.Problem
+			if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			var got []string
+			for _, field := range problem.Errors {
+				got = append(got, field.Field)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("error fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
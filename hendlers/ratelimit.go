@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"song-libary/ratelimit"
+	"song-libary/router"
+	"strconv"
+	"time"
+)
+
+// RateLimit ограничивает частоту запросов клиента к маршруту; ограничение выбирается по пути маршрута.
+// Ответ содержит заголовки RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset и RateLimit-Policy;
+// при превышении ограничения возвращается 429 с заголовком Retry-After
+func RateLimit(limiter *ratelimit.Limiter) router.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := router.Path(r)
+			rate := limiter.Rate(path)
+			if rate.Requests == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			result := limiter.Allow(r, path)
+
+			limit := strconv.Itoa(rate.Requests)
+			header := w.Header()
+			header.Set("RateLimit-Limit", limit)
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", seconds(result.Reset))
+			header.Set("RateLimit-Policy", limit+";w="+seconds(rate.Per))
+
+			if !result.Allowed {
+				header.Set("Retry-After", seconds(result.RetryAfter))
+				writeProblem(w, newProblem(r, http.StatusTooManyRequests, "rate_limited", "Too many requests, retry later"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// seconds округляет длительность вверх до целых секунд
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"song-libary/config"
+	"song-libary/ratelimit"
+	"song-libary/router"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	// Токены пополняются раз в полчаса и больше, поэтому за время теста корзины не пополняются
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), config.RateLimitConfig{
+		Enabled: true,
+		Default: config.Rate{Requests: 5, Per: time.Hour},
+		Routes: map[string]config.Rate{
+			"/songs":   {Requests: 2, Per: time.Hour},
+			"/healthz": {},
+		},
+		APIKeyHeader: "X-API-Key",
+		APIKeys:      []string{"known-key"},
+	}, discardLogger)
+
+	rt := router.New(nil, nil)
+	rt.Use(RateLimit(limiter))
+	for _, path := range []string{"/songs", "/songs/info", "/healthz"} {
+		rt.Get(path, func(w http.ResponseWriter, r *http.Request) {})
+	}
+
+	// Запросы выполняются по порядку и расходуют общие корзины
+	steps := []struct {
+		name       string
+		target     string
+		remoteAddr string
+		apiKey     string
+		wantStatus int
+		wantLimit  string
+		wantRemain string
+		wantReset  string
+		wantRetry  string
+	}{
+		{name: "first request", target: "/songs", remoteAddr: "192.0.2.1:1000", wantStatus: http.StatusOK, wantLimit: "2", wantRemain: "1", wantReset: "1800"},
+		{name: "last token", target: "/songs", remoteAddr: "192.0.2.1:1001", wantStatus: http.StatusOK, wantLimit: "2", wantRemain: "0", wantReset: "3600"},
+		{name: "limit exceeded", target: "/songs", remoteAddr: "192.0.2.1:1002", wantStatus: http.StatusTooManyRequests, wantLimit: "2", wantRemain: "0", wantReset: "3600", wantRetry: "1800"},
+		{name: "unknown key counted by IP", target: "/songs", remoteAddr: "192.0.2.1:1003", apiKey: "guess", wantStatus: http.StatusTooManyRequests, wantLimit: "2", wantRemain: "0", wantReset: "3600", wantRetry: "1800"},
+		{name: "known key has own bucket", target: "/songs", remoteAddr: "192.0.2.1:1004", apiKey: "known-key", wantStatus: http.StatusOK, wantLimit: "2", wantRemain: "1", wantReset: "1800"},
+		{name: "other IP has own bucket", target: "/songs", remoteAddr: "192.0.2.2:1000", wantStatus: http.StatusOK, wantLimit: "2", wantRemain: "1", wantReset: "1800"},
+		{name: "other route uses default limit", target: "/songs/info", remoteAddr: "192.0.2.1:1005", wantStatus: http.StatusOK, wantLimit: "5", wantRemain: "4", wantReset: "720"},
+		{name: "route without limit", target: "/healthz", remoteAddr: "192.0.2.1:1006", wantStatus: http.StatusOK},
+	}
+	for _, step := range steps {
+		r := httptest.NewRequest(http.MethodGet, step.target, nil)
+		r.RemoteAddr = step.remoteAddr
+		if step.apiKey != "" {
+			r.Header.Set("X-API-Key", step.apiKey)
+		}
+		w := httptest.NewRecorder()
+		rt.ServeHTTP(w, r)
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d", step.name, w.Code, step.wantStatus)
+		}
+		header := w.Header()
+		wantPolicy := ""
+		if step.wantLimit != "" {
+			wantPolicy = step.wantLimit + ";w=3600" // Оба ограничения теста — за час
+		}
+		for name, want := range map[string]string{
+			"RateLimit-Limit":     step.wantLimit,
+			"RateLimit-Remaining": step.wantRemain,
+			"RateLimit-Reset":     step.wantReset,
+			"RateLimit-Policy":    wantPolicy,
+			"Retry-After":         step.wantRetry,
+		} {
+			if got := header.Get(name); got != want {
+				t.Errorf("%s: %s = %q, want %q", step.name, name, got, want)
+			}
+		}
+		if step.wantStatus == http.StatusTooManyRequests {
+			if problem := decodeProblem(t, w); problem.Code != "rate_limited" {
+				t.Errorf("%s: code = %q, want rate_limited", step.name, problem.Code)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"song-libary/service"
+)
+
+// GetSimilarSongsHandler возвращает песни с похожими текстами
+// @Summary Похожие песни
+// @Description Возвращает песни, тексты которых ближе всего к тексту заданной (TF-IDF и косинусная близость). Надбавки group_boost и genre_boost повышают оценку песен той же группы или с общим жанром
+// @Tags Песни
+// @Produce json
+// @Param id path string true "UUID песни" example("8c5f2f9e-2d3b-4a6b-9d43-2f0c8f4f6a11")
+// @Param limit query int false "Количество песен" default(10) example(5)
+// @Param group_boost query number false "Надбавка за ту же группу (0–1)" default(0) example(0.1)
+// @Param genre_boost query number false "Надбавка за общий жанр (0–1)" default(0) example(0.05)
+// @Success 200 {array} models.SimilarSong "Похожие песни"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/{id}/similar [get]
+func (h *SongHandler) GetSimilarSongsHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to fetch similar songs")
+
+	query := newQueryParser(r)
+	opts := service.SimilarityOptions{Limit: service.DefaultSimilarLimit}
+	if limit := query.Int("limit"); limit != nil {
+		opts.Limit = *limit
+	}
+	if boost := query.Float("group_boost"); boost != nil {
+		opts.GroupBoost = *boost
+	}
+	if boost := query.Float("genre_boost"); boost != nil {
+		opts.GenreBoost = *boost
+	}
+	if !validateQuery(h.Logger, w, r, query, nil) {
+		return
+	}
+
+	similar, err := h.Service.GetSimilarSongs(r.Context(), r.PathValue("id"), opts)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to fetch similar songs")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, similar)
+}
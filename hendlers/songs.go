@@ -2,20 +2,22 @@ package handlers
 
 import (
 	"encoding/json"
-	"errors"
-	"log"
+	"log/slog"
 	"net/http"
 	"song-libary/models"
 	"song-libary/service"
 	"strconv"
+	"strings"
 )
 
 type SongHandler struct {
-	Service *service.SongService
+	Service      *service.SongService
+	Translations *service.TranslationService
+	Logger       *slog.Logger
 }
 
-func NewSongHandler(service *service.SongService) *SongHandler {
-	return &SongHandler{Service: service}
+func NewSongHandler(service *service.SongService, translations *service.TranslationService, logger *slog.Logger) *SongHandler {
+	return &SongHandler{Service: service, Translations: translations, Logger: logger}
 }
 
 // AddSongHandler добавляет новую песню
@@ -26,43 +28,30 @@ func NewSongHandler(service *service.SongService) *SongHandler {
 // @Produce json
 // @Param request body models.AddSongRequest true "Детали новой песни"
 // @Success 201 {object} models.DefaultResponse "Песня успешно добавлена"
-// @Failure 400 {object} models.DefaultResponse "Ошибка в запросе"
-// @Failure 500 {object} models.DefaultResponse "Ошибка сервера"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 409 {object} models.Problem "ISRC уже занят"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
 // @Router /songs/add [post]
 func (h *SongHandler) AddSongHandler(w http.ResponseWriter, r *http.Request) {
-	log.Println("[INFO] Received request to add a new song")
-
-	if r.Method != http.MethodPost {
-		log.Printf("[ERROR] Method not allowed: %s", r.Method)
-		response := models.DefaultResponse{
-			Message: "Method not allowed",
-			Status:  http.StatusMethodNotAllowed,
-		}
-		h.writeJSONResponse(w, http.StatusMethodNotAllowed, response)
-		return
-	}
+	h.Logger.InfoContext(r.Context(), "Received request to add a new song")
 
 	var request models.AddSongRequest
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		log.Printf("[ERROR] Failed to decode request body: %v", err)
-		response := models.DefaultResponse{
-			Message: "Invalid request body",
-			Status:  http.StatusBadRequest,
-		}
-		h.writeJSONResponse(w, http.StatusBadRequest, response)
+		writeInvalidBody(h.Logger, w, r, err)
+		return
+	}
+	if !validateRequest(h.Logger, w, r, request) {
 		return
 	}
 
-	log.Printf("[DEBUG] Request data: %+v", request)
+	h.Logger.DebugContext(r.Context(), "Request data", "song", request.Song, "group", request.Group)
 
-	_, err := h.Service.AddSong(request.Group, request.Song, request.Text, request.ReleaseDate, request.Link)
+	_, err := h.Service.AddSong(r.Context(), request)
 	if err != nil {
-		log.Printf("[ERROR] Failed to save song to database: %v", err)
-		response := models.DefaultResponse{
-			Message: "Failed to save song to database",
-			Status:  http.StatusInternalServerError,
-		}
-		h.writeJSONResponse(w, http.StatusInternalServerError, response)
+		writeError(h.Logger, w, r, err, "Failed to save song to database")
 		return
 	}
 
@@ -71,8 +60,8 @@ func (h *SongHandler) AddSongHandler(w http.ResponseWriter, r *http.Request) {
 		Status:  http.StatusCreated,
 	}
 
-	log.Println("[INFO] Song added successfully")
-	h.writeJSONResponse(w, http.StatusCreated, response)
+	h.Logger.InfoContext(r.Context(), "Song added successfully")
+	writeJSONResponse(w, http.StatusCreated, response)
 }
 
 // DeleteSongHandler удаляет песню по названию
@@ -84,56 +73,29 @@ func (h *SongHandler) AddSongHandler(w http.ResponseWriter, r *http.Request) {
 // @Param song_name query string true "Название песни" example("Supermassive Black Hole")
 // @Param group query string true "Название группы" example("Muse")
 // @Success 200 {object} models.DefaultResponse "Песня успешно удалена"
-// @Failure 400 {object} models.DefaultResponse "Ошибка в запросе"
-// @Failure 404 {object} models.DefaultResponse "Песня не найдена"
-// @Failure 500 {object} models.DefaultResponse "Ошибка сервера"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
 // @Router /songs/delete [delete]
 func (h *SongHandler) DeleteSongHandler(w http.ResponseWriter, r *http.Request) {
-	log.Println("[INFO] Received request to delete song")
+	h.Logger.InfoContext(r.Context(), "Received request to delete song")
 
-	if r.Method != http.MethodDelete {
-		log.Printf("[ERROR] Method not allowed: %s", r.Method)
-		response := models.DefaultResponse{
-			Message: "Method not allowed",
-			Status:  http.StatusMethodNotAllowed,
-		}
-		h.writeJSONResponse(w, http.StatusMethodNotAllowed, response)
+	// Читаем параметр `song_name` из запроса
+	if !requireParams(h.Logger, w, r, "song_name", "group") {
 		return
 	}
-
-	// Читаем параметр `song_name` из запроса
 	songName := r.URL.Query().Get("song_name")
 	group := r.URL.Query().Get("group")
-	if songName == "" || group == "" {
-		log.Printf("[ERROR] Missing query parameter: song_name, group")
-		response := models.DefaultResponse{
-			Message: "Missing query parameter: song_name, group",
-			Status:  http.StatusBadRequest,
-		}
-		h.writeJSONResponse(w, http.StatusBadRequest, response)
-		return
-	}
 
-	log.Printf("[DEBUG] Song name to delete: %s", songName)
+	h.Logger.DebugContext(r.Context(), "Song name to delete", "song", songName)
 
 	// Вызываем сервис для удаления песни
-	err := h.Service.DeleteSongByNameAndGroup(songName, group)
+	err := h.Service.DeleteSongByNameAndGroup(r.Context(), songName, group)
 	if err != nil {
-		if errors.Is(err, service.ErrSongNotFound) {
-			log.Printf("[INFO] Song not found: %s", songName)
-			response := models.DefaultResponse{
-				Message: "Song not found",
-				Status:  http.StatusNotFound,
-			}
-			h.writeJSONResponse(w, http.StatusNotFound, response)
-			return
-		}
-		log.Printf("[ERROR] Failed to delete song: %v", err)
-		response := models.DefaultResponse{
-			Message: "Failed to delete song",
-			Status:  http.StatusInternalServerError,
-		}
-		h.writeJSONResponse(w, http.StatusInternalServerError, response)
+		writeError(h.Logger, w, r, err, "Failed to delete song")
 		return
 	}
 
@@ -142,62 +104,42 @@ func (h *SongHandler) DeleteSongHandler(w http.ResponseWriter, r *http.Request)
 		Message: "Song deleted successfully",
 		Status:  http.StatusOK,
 	}
-	h.writeJSONResponse(w, http.StatusOK, response)
+	writeJSONResponse(w, http.StatusOK, response)
 }
 
 // UpdateSongHandler обновляет данные песни
 // @Summary Обновление данных песни
-// @Description Обновление информации о песне, включая название, группу и текст
+// @Description Обновление информации о песне, включая название, группу, текст и метаданные трека
 // @Tags Песни
 // @Accept json
 // @Produce json
 // @Param request body models.UpdateSongRequest true "Обновленные данные песни"
 // @Success 200 {object} models.DefaultResponse "Песня успешно обновлена"
-// @Failure 400 {object} models.DefaultResponse "Ошибка в запросе"
-// @Failure 404 {object} models.DefaultResponse "Песня не найдена"
-// @Failure 500 {object} models.DefaultResponse "Ошибка сервера"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 409 {object} models.Problem "ISRC уже занят"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
 // @Router /songs/update [put]
 func (h *SongHandler) UpdateSongHandler(w http.ResponseWriter, r *http.Request) {
-	log.Println("[INFO] Received request to update song")
-
-	if r.Method != http.MethodPut {
-		log.Printf("[ERROR] Method not allowed: %s", r.Method)
-		response := models.DefaultResponse{
-			Message: "Method not allowed",
-			Status:  http.StatusMethodNotAllowed,
-		}
-		h.writeJSONResponse(w, http.StatusMethodNotAllowed, response)
-		return
-	}
+	h.Logger.InfoContext(r.Context(), "Received request to update song")
 
 	var request models.UpdateSongRequest
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		log.Printf("[ERROR] Failed to decode request body: %v", err)
-		response := models.DefaultResponse{
-			Message: "Invalid request body",
-			Status:  http.StatusBadRequest,
-		}
-		h.writeJSONResponse(w, http.StatusBadRequest, response)
+		writeInvalidBody(h.Logger, w, r, err)
+		return
+	}
+	if !validateRequest(h.Logger, w, r, request) {
 		return
 	}
 
-	log.Printf("[DEBUG] Update request: %+v", request)
+	h.Logger.DebugContext(r.Context(), "Update request", "old_song_name", request.OldSongName, "old_group", request.OldGroup)
 
-	err := h.Service.UpdateSong(request)
+	err := h.Service.UpdateSong(r.Context(), request)
 	if err != nil {
-		if errors.Is(err, service.ErrSongNotFound) {
-			response := models.DefaultResponse{
-				Message: "Song not found for update",
-				Status:  http.StatusNotFound,
-			}
-			h.writeJSONResponse(w, http.StatusNotFound, response)
-			return
-		}
-		response := models.DefaultResponse{
-			Message: "Failed to update song",
-			Status:  http.StatusInternalServerError,
-		}
-		h.writeJSONResponse(w, http.StatusInternalServerError, response)
+		writeError(h.Logger, w, r, err, "Failed to update song")
 		return
 	}
 
@@ -205,7 +147,7 @@ func (h *SongHandler) UpdateSongHandler(w http.ResponseWriter, r *http.Request)
 		Message: "Song updated successfully",
 		Status:  http.StatusOK,
 	}
-	h.writeJSONResponse(w, http.StatusOK, response)
+	writeJSONResponse(w, http.StatusOK, response)
 }
 
 // GetSongsHandler обрабатывает запрос на получение песен с фильтрацией и пагинацией
@@ -218,67 +160,55 @@ func (h *SongHandler) UpdateSongHandler(w http.ResponseWriter, r *http.Request)
 // @Param song query string false "Название песни" example("Hysteria")
 // @Param text query string false "Текст песни" example("It's bugging me, grating me")
 // @Param release_date query string false "Дата релиза" example("2003-12-15")
-// @Param limit query int false "Лимит песен на страницу" default(10) example(5)
-// @Param offset query int false "Смещение для пагинации" default(0) example(10)
+// @Param genre query string false "Жанр (включая поджанры)" example("rock")
+// @Param tags query string false "Теги через запятую" example("ballad,live")
+// @Param tags_match query string false "Режим совпадения тегов" Enums(any, all) default(any)
+// @Param credited query string false "Участник работы над песней (исполнитель, автор, продюсер)" example("Matthew Bellamy")
+// @Param bpm_min query int false "Минимальный темп" example(110)
+// @Param bpm_max query int false "Максимальный темп" example(130)
+// @Param duration_min query int false "Минимальная длительность, мс" example(180000)
+// @Param duration_max query int false "Максимальная длительность, мс" example(300000)
+// @Param key query string false "Тональность" example("C#m")
+// @Param language query string false "Язык текста (ISO 639-1)" example("en")
+// @Param link_status query string false "Песни со ссылками в указанном состоянии" Enums(unchecked, ok, broken)
+// @Param no_explicit query bool false "Исключить песни с ненормативной лексикой" default(false)
+// @Param limit query int false "Лимит песен на страницу" default(10) minimum(1) maximum(100) example(5)
+// @Param offset query int false "Смещение для пагинации" default(0) minimum(0) example(10)
 // @Success 200 {array} models.Song "Список песен"
-// @Failure 400 {object} models.DefaultResponse "Ошибка в запросе"
-// @Failure 500 {object} models.DefaultResponse "Ошибка сервера"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
 // @Router /songs [get]
 func (h *SongHandler) GetSongsHandler(w http.ResponseWriter, r *http.Request) {
-	log.Println("[INFO] Received request to fetch songs")
-
-	if r.Method != http.MethodGet {
-		log.Printf("[ERROR] Method not allowed: %s", r.Method)
-		response := models.DefaultResponse{
-			Message: "Method not allowed",
-			Status:  http.StatusMethodNotAllowed,
-		}
-		h.writeJSONResponse(w, http.StatusMethodNotAllowed, response)
+	h.Logger.InfoContext(r.Context(), "Received request to fetch songs")
+
+	// Читаем параметры фильтрации и пагинации; границы проверяются вместе с фильтрами
+	query := newQueryParser(r)
+	params := parseFilterParams(query)
+	page := query.Page(10) // Значение по умолчанию
+	params.Limit, params.Offset = page.Limit, page.Offset
+	if !validateQuery(h.Logger, w, r, query, params) {
 		return
 	}
 
-	// Читаем параметры фильтрации и пагинации
-	params := models.FilterParams{
-		Group:       r.URL.Query().Get("group"),
-		SongName:    r.URL.Query().Get("song"),
-		Text:        r.URL.Query().Get("text"),
-		ReleaseDate: r.URL.Query().Get("release_date"),
-	}
-
-	// Параметры пагинации
-	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
-	if err != nil || limit <= 0 {
-		limit = 10 // Значение по умолчанию
-	}
-	params.Limit = limit
-
-	offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
-	if err != nil || offset < 0 {
-		offset = 0 // Значение по умолчанию
-	}
-	params.Offset = offset
-
-	log.Printf("[DEBUG] Filter and pagination params: %+v", params)
+	h.Logger.DebugContext(r.Context(), "Filter and pagination params", "params", params)
 
 	// Вызываем сервис для получения песен
-	songs, err := h.Service.GetSongs(params)
+	songs, err := h.Service.GetSongs(r.Context(), params)
 	if err != nil {
-		log.Printf("[ERROR] Failed to fetch songs: %v", err)
-		response := models.DefaultResponse{
-			Message: "Failed to fetch songs",
-			Status:  http.StatusInternalServerError,
-		}
-		h.writeJSONResponse(w, http.StatusInternalServerError, response)
+		writeError(h.Logger, w, r, err, "Failed to fetch songs")
 		return
 	}
 
 	// Отправляем результат
-	h.writeJSONResponse(w, http.StatusOK, songs)
+	writeJSONResponse(w, http.StatusOK, songs)
 }
 
 // GetSongTextHandler обрабатывает запрос на получение текста песни с пагинацией
 // @Summary Получение текста песни с пагинацией
-// @Description Возвращает текст песни с разбивкой на куплеты и поддержкой пагинации
+// @Description Возвращает текст песни с разбивкой на куплеты и поддержкой пагинации. С параметром lang возвращается перевод, а с parallel=true — пары куплетов оригинала и перевода. С clean=true ненормативная лексика заменяется звёздочками, а с rhymes=true для каждого куплета возвращается схема рифмовки
 // @Tags Песни
 // @Accept json
 // @Produce json
@@ -286,36 +216,27 @@ func (h *SongHandler) GetSongsHandler(w http.ResponseWriter, r *http.Request) {
 // @Param group query string true "Название группы" example("Queen")
 // @Param limit query int false "Лимит куплетов на страницу" default(3) example(2)
 // @Param offset query int false "Смещение для пагинации" default(0) example(1)
+// @Param lang query string false "Код языка перевода" example("ru")
+// @Param parallel query bool false "Вернуть оригинал и перевод парами (требует lang)" default(false)
+// @Param clean query bool false "Замаскировать ненормативную лексику" default(false)
+// @Param rhymes query bool false "Добавить схемы рифмовки куплетов" default(false)
 // @Success 200 {object} models.SongTextResponse "Текст песни с пагинацией"
-// @Failure 400 {object} models.DefaultResponse "Ошибка в запросе"
-// @Failure 404 {object} models.DefaultResponse "Песня не найдена"
-// @Failure 500 {object} models.DefaultResponse "Ошибка сервера"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
 // @Router /songs/text [get]
 func (h *SongHandler) GetSongTextHandler(w http.ResponseWriter, r *http.Request) {
-	log.Println("[INFO] Received request to fetch song text with pagination")
+	h.Logger.InfoContext(r.Context(), "Received request to fetch song text with pagination")
 
-	if r.Method != http.MethodGet {
-		log.Printf("[ERROR] Method not allowed: %s", r.Method)
-		response := models.DefaultResponse{
-			Message: "Method not allowed",
-			Status:  http.StatusMethodNotAllowed,
-		}
-		h.writeJSONResponse(w, http.StatusMethodNotAllowed, response)
+	// Получаем параметры запроса
+	if !requireParams(h.Logger, w, r, "song_name", "group") {
 		return
 	}
-
-	// Получаем параметры запроса
 	songName := r.URL.Query().Get("song_name")
 	group := r.URL.Query().Get("group")
-	if songName == "" || group == "" {
-		log.Printf("[ERROR] Missing query parameter: song_name, group")
-		response := models.DefaultResponse{
-			Message: "Missing query parameter: song_name, group",
-			Status:  http.StatusBadRequest,
-		}
-		h.writeJSONResponse(w, http.StatusBadRequest, response)
-		return
-	}
 
 	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
 	if err != nil || limit <= 0 {
@@ -327,90 +248,111 @@ func (h *SongHandler) GetSongTextHandler(w http.ResponseWriter, r *http.Request)
 		offset = 0 // Значение по умолчанию
 	}
 
-	// Вызываем сервис для получения текста песни
-	response, err := h.Service.GetSongText(songName, group, limit, offset)
-	if err != nil {
-		if errors.Is(err, service.ErrSongNotFound) {
-			log.Printf("[INFO] Song not found: %s", songName)
-			errorResponse := models.DefaultResponse{
-				Message: "Song not found",
-				Status:  http.StatusNotFound,
-			}
-			h.writeJSONResponse(w, http.StatusNotFound, errorResponse)
+	params := models.SongTextParams{
+		Limit:    limit,
+		Offset:   offset,
+		Lang:     r.URL.Query().Get("lang"),
+		Parallel: r.URL.Query().Get("parallel") == "true",
+		Clean:    r.URL.Query().Get("clean") == "true",
+		Rhymes:   r.URL.Query().Get("rhymes") == "true",
+	}
+	if params.Parallel && params.Lang == "" {
+		field := models.FieldError{Field: "lang", Code: "required", Message: "is required in parallel mode"}
+		writeError(h.Logger, w, r, service.ValidationError(field), "")
+		return
+	}
+
+	// Перевод запрашивается у отдельного сервиса
+	if params.Lang != "" {
+		response, err := h.Translations.GetTranslatedText(r.Context(), songName, group, params)
+		if err != nil {
+			writeError(h.Logger, w, r, err, "Failed to fetch song translation")
 			return
 		}
+		writeJSONResponse(w, http.StatusOK, response)
+		return
+	}
 
-		log.Printf("[ERROR] Failed to fetch song text: %v", err)
-		errorResponse := models.DefaultResponse{
-			Message: "Failed to fetch song text",
-			Status:  http.StatusInternalServerError,
-		}
-		h.writeJSONResponse(w, http.StatusInternalServerError, errorResponse)
+	// Вызываем сервис для получения текста песни
+	response, err := h.Service.GetSongText(r.Context(), songName, group, params)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to fetch song text")
 		return
 	}
 
 	// Отправляем успешный ответ
-	h.writeJSONResponse(w, http.StatusOK, response)
+	writeJSONResponse(w, http.StatusOK, response)
 }
 
 // InfoHandler обрабатывает запрос на получение информации о песне
 // @Summary Получение информации о песне
-// @Description Возвращает информацию о песне, включая дату релиза, текст и ссылку
+// @Description Возвращает информацию о песне, включая дату релиза, текст, жанры, теги, участников и ссылки, сгруппированные по провайдеру
 // @Tags Песни
 // @Accept json
 // @Produce json
 // @Param group query string true "Название группы" example("Imagine Dragons")
 // @Param song_name query string true "Название песни" example("Radioactive")
 // @Success 200 {object} models.SongDetail "Детали песни"
-// @Failure 400 {object} models.DefaultResponse "Ошибка в запросе"
-// @Failure 500 {object} models.DefaultResponse "Ошибка сервера"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
 // @Router /songs/info [get]
 func (h *SongHandler) InfoHandler(w http.ResponseWriter, r *http.Request) {
-	log.Println("[INFO] Received request to get song info")
+	h.Logger.InfoContext(r.Context(), "Received request to get song info")
 
-	if r.Method != http.MethodGet {
-		log.Printf("[ERROR] Method not allowed: %s", r.Method)
-		response := models.DefaultResponse{
-			Message: "Method not allowed",
-			Status:  http.StatusMethodNotAllowed,
-		}
-		h.writeJSONResponse(w, http.StatusMethodNotAllowed, response)
+	// Получаем параметры из запроса
+	if !requireParams(h.Logger, w, r, "group", "song_name") {
 		return
 	}
-
-	// Получаем параметры из запроса
 	group := r.URL.Query().Get("group")
 	songName := r.URL.Query().Get("song_name")
-	if group == "" || songName == "" {
-		log.Printf("[ERROR] Missing required query parameters: group or song_name")
-		response := models.DefaultResponse{
-			Message: "Missing required query parameters: group or song_name",
-			Status:  http.StatusBadRequest,
-		}
-		h.writeJSONResponse(w, http.StatusBadRequest, response)
-		return
-	}
 
 	// Вызываем сервис для получения информации о песне
-	songDetail, err := h.Service.GetSongInfo(group, songName)
+	songDetail, err := h.Service.GetSongInfo(r.Context(), group, songName)
 	if err != nil {
-		log.Printf("[ERROR] Failed to fetch song info: %v", err)
-		response := models.DefaultResponse{
-			Message: "Failed to fetch song info",
-			Status:  http.StatusInternalServerError,
-		}
-		h.writeJSONResponse(w, http.StatusInternalServerError, response)
+		writeError(h.Logger, w, r, err, "Failed to fetch song info")
 		return
 	}
 
-	h.writeJSONResponse(w, http.StatusOK, songDetail)
+	writeJSONResponse(w, http.StatusOK, songDetail)
+}
+
+// parseFilterParams читает параметры фильтрации песен без пагинации; ошибки разбора
+// накапливаются в query
+func parseFilterParams(query *queryParser) models.FilterParams {
+	params := models.FilterParams{
+		Group:       query.String("group"),
+		SongName:    query.String("song"),
+		Text:        query.String("text"),
+		ReleaseDate: query.String("release_date"),
+		Genre:       query.String("genre"),
+		TagsMatch:   query.String("tags_match"),
+		Credited:    query.String("credited"),
+		Key:         query.String("key"),
+		LinkStatus:  query.String("link_status"),
+		Language:    query.String("language"),
+		NoExplicit:  query.String("no_explicit") == "true",
+	}
+	if tags := query.String("tags"); tags != "" {
+		params.Tags = strings.Split(tags, ",")
+	}
+
+	// Диапазонные фильтры метаданных трека
+	params.BPMMin = query.Int("bpm_min")
+	params.BPMMax = query.Int("bpm_max")
+	params.DurationMin = query.Int("duration_min")
+	params.DurationMax = query.Int("duration_max")
+	return params
 }
 
 // writeJSONResponse отправляет JSON-ответ с заданным статусом
-func (h *SongHandler) writeJSONResponse(w http.ResponseWriter, status int, response any) {
+func writeJSONResponse(w http.ResponseWriter, status int, response any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		log.Printf("[ERROR] Failed to encode response: %v", err)
+		slog.Error("Failed to encode response", "error", err)
 	}
 }
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"song-libary/service"
+)
+
+type StatsHandler struct {
+	Service *service.StatsService
+	Logger  *slog.Logger
+}
+
+func NewStatsHandler(service *service.StatsService, logger *slog.Logger) *StatsHandler {
+	return &StatsHandler{Service: service, Logger: logger}
+}
+
+// GetStatsHandler возвращает сводную статистику библиотеки
+// @Summary Статистика библиотеки
+// @Description Возвращает количество песен и групп, группы с наибольшим числом песен, распределение по годам и десятилетиям релиза, последние добавленные песни, среднюю длину текста и число песен без ссылки, текста или даты релиза. Результат кэшируется на короткое время
+// @Tags Статистика
+// @Produce json
+// @Success 200 {object} models.CatalogueStats "Статистика библиотеки"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /stats [get]
+func (h *StatsHandler) GetStatsHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to fetch catalogue stats")
+
+	stats, err := h.Service.GetStats(r.Context())
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to fetch catalogue stats")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, stats)
+}
+
+// GetGroupCountsHandler возвращает количество песен по группам
+// @Summary Количество песен по группам
+// @Description Возвращает группы, упорядоченные по убыванию числа песен, с пагинацией
+// @Tags Статистика
+// @Produce json
+// @Param limit query int false "Лимит групп на страницу" default(50) minimum(1) maximum(100) example(10)
+// @Param offset query int false "Смещение для пагинации" default(0) minimum(0) example(0)
+// @Success 200 {array} models.GroupCount "Количество песен по группам"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /stats/groups [get]
+func (h *StatsHandler) GetGroupCountsHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to fetch song counts per group")
+
+	query := newQueryParser(r)
+	page := query.Page(50) // Значение по умолчанию
+	if !validateQuery(h.Logger, w, r, query, page) {
+		return
+	}
+
+	groups, err := h.Service.GetGroupCounts(r.Context(), page.Limit, page.Offset)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to fetch song counts per group")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, groups)
+}
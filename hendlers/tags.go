@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"song-libary/models"
+	"song-libary/service"
+	"strings"
+)
+
+type TagHandler struct {
+	Service *service.TagService
+	Logger  *slog.Logger
+}
+
+func NewTagHandler(service *service.TagService, logger *slog.Logger) *TagHandler {
+	return &TagHandler{Service: service, Logger: logger}
+}
+
+// GetGenresHandler возвращает справочник жанров
+// @Summary Получение справочника жанров
+// @Description Возвращает все жанры; иерархия задаётся полем parent_id
+// @Tags Жанры и теги
+// @Produce json
+// @Success 200 {array} models.Genre "Список жанров"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /genres [get]
+func (h *TagHandler) GetGenresHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to fetch genres")
+
+	genres, err := h.Service.GetGenres(r.Context())
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to fetch genres")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, genres)
+}
+
+// AddGenreHandler добавляет жанр в справочник
+// @Summary Добавление жанра
+// @Description Добавление жанра в справочник; parent задаёт родительский жанр (например, rock для alt-rock)
+// @Tags Жанры и теги
+// @Accept json
+// @Produce json
+// @Param request body models.AddGenreRequest true "Новый жанр"
+// @Success 201 {object} models.Genre "Жанр успешно добавлен"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Родительский жанр не найден"
+// @Failure 409 {object} models.Problem "Жанр уже есть в справочнике"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /genres/add [post]
+func (h *TagHandler) AddGenreHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to add a genre")
+
+	var request models.AddGenreRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeInvalidBody(h.Logger, w, r, err)
+		return
+	}
+
+	genre, err := h.Service.AddGenre(r.Context(), request)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to add genre")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, genre)
+}
+
+// TagSongHandler добавляет песне жанры и теги
+// @Summary Добавление жанров и тегов к песне
+// @Description Привязывает к песне жанры из справочника и произвольные теги (новые теги создаются автоматически)
+// @Tags Жанры и теги
+// @Accept json
+// @Produce json
+// @Param request body models.TagSongRequest true "Песня, жанры и теги"
+// @Success 200 {object} models.DefaultResponse "Жанры и теги добавлены"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня или жанр не найдены"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/tags/add [post]
+func (h *TagHandler) TagSongHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to tag song")
+
+	var request models.TagSongRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeInvalidBody(h.Logger, w, r, err)
+		return
+	}
+
+	if err := h.Service.TagSong(r.Context(), request); err != nil {
+		writeError(h.Logger, w, r, err, "Failed to tag song")
+		return
+	}
+
+	response := models.DefaultResponse{
+		Message: "Song tagged successfully",
+		Status:  http.StatusOK,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// UntagSongHandler удаляет у песни жанры и теги
+// @Summary Удаление жанров и тегов у песни
+// @Description Отвязывает от песни перечисленные через запятую жанры и теги
+// @Tags Жанры и теги
+// @Produce json
+// @Param song_name query string true "Название песни" example("Hysteria")
+// @Param group query string true "Название группы" example("Muse")
+// @Param genres query string false "Жанры через запятую" example("alt-rock")
+// @Param tags query string false "Теги через запятую" example("ballad,live")
+// @Success 200 {object} models.DefaultResponse "Жанры и теги удалены"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/tags/delete [delete]
+func (h *TagHandler) UntagSongHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to untag song")
+
+	if !requireParams(h.Logger, w, r, "song_name", "group") {
+		return
+	}
+	songName := r.URL.Query().Get("song_name")
+	group := r.URL.Query().Get("group")
+
+	request := models.TagSongRequest{Group: group, Song: songName}
+	if genres := r.URL.Query().Get("genres"); genres != "" {
+		request.Genres = strings.Split(genres, ",")
+	}
+	if tags := r.URL.Query().Get("tags"); tags != "" {
+		request.Tags = strings.Split(tags, ",")
+	}
+
+	if err := h.Service.UntagSong(r.Context(), request); err != nil {
+		writeError(h.Logger, w, r, err, "Failed to untag song")
+		return
+	}
+
+	response := models.DefaultResponse{
+		Message: "Song untagged successfully",
+		Status:  http.StatusOK,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"song-libary/router"
+	"time"
+)
+
+// Timeout ограничивает время обработки запроса дедлайном timeoutFor(путь маршрута): по его истечении
+// контекст запроса отменяется, вместе с ним прерываются запросы к базе данных. Дедлайн 0 — без ограничения
+func Timeout(timeoutFor func(path string) time.Duration) router.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout := timeoutFor(router.Path(r))
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"song-libary/router"
+	"testing"
+	"time"
+)
+
+func TestTimeoutUsesRouteDeadline(t *testing.T) {
+	timeouts := map[string]time.Duration{"/songs/{id}": time.Minute, "/stats": 20 * time.Minute}
+
+	var routes []string
+	deadlines := make(map[string]time.Duration)
+	rt := router.New(nil, nil)
+	rt.Use(Timeout(func(path string) time.Duration {
+		routes = append(routes, path)
+		return timeouts[path]
+	}))
+	for _, path := range []string{"/songs/{id}", "/stats", "/healthz"} {
+		rt.Get(path, func(w http.ResponseWriter, r *http.Request) {
+			if deadline, ok := r.Context().Deadline(); ok {
+				deadlines[path] = time.Until(deadline)
+			}
+		})
+	}
+
+	for _, target := range []string{"/songs/42", "/stats", "/healthz"} {
+		rt.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	if want := []string{"/songs/{id}", "/stats", "/healthz"}; !slices.Equal(routes, want) {
+		t.Errorf("timeouts requested for %v, want route patterns %v", routes, want)
+	}
+	for path, timeout := range timeouts {
+		if got, ok := deadlines[path]; !ok || got <= 0 || got > timeout {
+			t.Errorf("%s: time to deadline = %v (set %v), want at most %v", path, got, ok, timeout)
+		}
+	}
+	if _, ok := deadlines["/healthz"]; ok {
+		t.Error("/healthz: deadline set for a route without timeout")
+	}
+}
+
+func TestTimeoutResponses(t *testing.T) {
+	// Обработчик ждёт отмены контекста, как запрос к базе данных, и сообщает о ней ошибкой
+	waitForContext := func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		writeError(discardLogger, w, r, r.Context().Err(), "Failed to fetch songs")
+	}
+
+	tests := []struct {
+		name       string
+		timeout    time.Duration
+		cancel     bool // Клиент закрывает соединение до дедлайна
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "deadline exceeded", timeout: 10 * time.Millisecond, wantStatus: http.StatusGatewayTimeout, wantCode: "timeout"},
+		{name: "client canceled", timeout: time.Minute, cancel: true, wantStatus: http.StatusServiceUnavailable, wantCode: "database_unavailable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Timeout(func(string) time.Duration { return tt.timeout })(http.HandlerFunc(waitForContext))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				time.AfterFunc(10*time.Millisecond, cancel)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/songs", nil).WithContext(ctx))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if problem := decodeProblem(t, w); problem.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", problem.Code, tt.wantCode)
+			}
+		})
+	}
+}
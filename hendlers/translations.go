@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"song-libary/models"
+	"song-libary/service"
+)
+
+type TranslationHandler struct {
+	Service *service.TranslationService
+	Logger  *slog.Logger
+}
+
+func NewTranslationHandler(service *service.TranslationService, logger *slog.Logger) *TranslationHandler {
+	return &TranslationHandler{Service: service, Logger: logger}
+}
+
+// GetTranslationsHandler возвращает переводы песни
+// @Summary Получение переводов песни
+// @Description Возвращает все переводы текста песни
+// @Tags Переводы
+// @Produce json
+// @Param song_name query string true "Название песни" example("Hysteria")
+// @Param group query string true "Название группы" example("Muse")
+// @Success 200 {array} models.Translation "Список переводов"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/translations [get]
+func (h *TranslationHandler) GetTranslationsHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to fetch song translations")
+
+	if !requireParams(h.Logger, w, r, "song_name", "group") {
+		return
+	}
+	songName := r.URL.Query().Get("song_name")
+	group := r.URL.Query().Get("group")
+
+	translations, err := h.Service.GetTranslations(r.Context(), songName, group)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to fetch song translations")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, translations)
+}
+
+// AddTranslationHandler добавляет перевод песни
+// @Summary Добавление перевода песни
+// @Description Добавляет перевод текста песни на указанный язык. Куплеты разделяются так же, как в оригинале
+// @Tags Переводы
+// @Accept json
+// @Produce json
+// @Param request body models.AddTranslationRequest true "Перевод"
+// @Success 201 {object} models.Translation "Перевод успешно добавлен"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Песня не найдена"
+// @Failure 409 {object} models.Problem "Перевод на этот язык уже существует"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/translations/add [post]
+func (h *TranslationHandler) AddTranslationHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to add song translation")
+
+	var request models.AddTranslationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeInvalidBody(h.Logger, w, r, err)
+		return
+	}
+
+	translation, err := h.Service.AddTranslation(r.Context(), request)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to add song translation")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, translation)
+}
+
+// UpdateTranslationHandler изменяет перевод песни
+// @Summary Изменение перевода песни
+// @Description Изменяет текст, автора и статус перевода на указанный язык
+// @Tags Переводы
+// @Accept json
+// @Produce json
+// @Param request body models.UpdateTranslationRequest true "Новые данные перевода"
+// @Success 200 {object} models.Translation "Перевод успешно обновлён"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Перевод не найден"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/translations/update [put]
+func (h *TranslationHandler) UpdateTranslationHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to update song translation")
+
+	var request models.UpdateTranslationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeInvalidBody(h.Logger, w, r, err)
+		return
+	}
+
+	translation, err := h.Service.UpdateTranslation(r.Context(), request)
+	if err != nil {
+		writeError(h.Logger, w, r, err, "Failed to update song translation")
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, translation)
+}
+
+// DeleteTranslationHandler удаляет перевод песни
+// @Summary Удаление перевода песни
+// @Description Удаляет перевод текста песни на указанный язык
+// @Tags Переводы
+// @Produce json
+// @Param song_name query string true "Название песни" example("Hysteria")
+// @Param group query string true "Название группы" example("Muse")
+// @Param lang query string true "Код языка" example("ru")
+// @Success 200 {object} models.DefaultResponse "Перевод успешно удалён"
+// @Failure 400 {object} models.Problem "Ошибка в запросе"
+// @Failure 404 {object} models.Problem "Перевод не найден"
+// @Failure 429 {object} models.Problem "Превышено ограничение частоты запросов"
+// @Failure 500 {object} models.Problem "Ошибка сервера"
+// @Failure 503 {object} models.Problem "База данных недоступна"
+// @Failure 504 {object} models.Problem "Превышено время обработки запроса"
+// @Router /songs/translations/delete [delete]
+func (h *TranslationHandler) DeleteTranslationHandler(w http.ResponseWriter, r *http.Request) {
+	h.Logger.InfoContext(r.Context(), "Received request to delete song translation")
+
+	if !requireParams(h.Logger, w, r, "song_name", "group", "lang") {
+		return
+	}
+	songName := r.URL.Query().Get("song_name")
+	group := r.URL.Query().Get("group")
+	lang := r.URL.Query().Get("lang")
+
+	if err := h.Service.DeleteTranslation(r.Context(), songName, group, lang); err != nil {
+		writeError(h.Logger, w, r, err, "Failed to delete song translation")
+		return
+	}
+
+	response := models.DefaultResponse{
+		Message: "Song translation deleted successfully",
+		Status:  http.StatusOK,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,40 @@
+// Package httpx содержит вспомогательные типы для HTTP middleware
+package httpx
+
+import "net/http"
+
+// StatusRecorder запоминает код ответа, записанный обработчиком. Если обработчик не вызвал
+// WriteHeader, ответ отправлен со статусом 200
+type StatusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+// Status возвращает код ответа
+func (r *StatusRecorder) Status() int {
+	return r.status
+}
+
+// WriteHeader запоминает код ответа; как и в net/http, учитывается только первый вызов
+func (r *StatusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *StatusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap позволяет http.ResponseController добраться до исходного ResponseWriter
+func (r *StatusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
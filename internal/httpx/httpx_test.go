@@ -0,0 +1,53 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatusRecorder(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    int
+	}{
+		{
+			name:    "implicit ok",
+			handler: func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) },
+			want:    http.StatusOK,
+		},
+		{
+			name:    "explicit status",
+			handler: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNotFound) },
+			want:    http.StatusNotFound,
+		},
+		{
+			name: "superfluous write header ignored",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			want: http.StatusCreated,
+		},
+		{
+			name: "write header after body ignored",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("ok"))
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			want: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			recorder := NewStatusRecorder(w)
+			tt.handler(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if recorder.Status() != tt.want || w.Code != tt.want {
+				t.Errorf("Status() = %d, response %d, want %d", recorder.Status(), w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+// Package langdetect определяет язык текста по n-граммам символов.
+// Профили языков строятся из встроенных в бинарник образцов текста,
+// поэтому определение работает полностью офлайн.
+package langdetect
+
+import (
+	"embed"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:embed corpus/*.txt
+var corpus embed.FS
+
+// maxNgram — наибольшая длина учитываемых n-грамм
+const maxNgram = 3
+
+// minLetters — минимальное число букв, при котором результат имеет смысл
+const minLetters = 10
+
+// confidenceSharpness задаёт резкость softmax по средним логарифмам правдоподобия на n-грамму:
+// при ней однозначный текст получает уверенность около 0.99, а смешанный — заметно меньше
+const confidenceSharpness = 8
+
+// Result представляет результат определения языка
+type Result struct {
+	Lang       string  // Код языка ISO 639-1 или пустая строка, если язык не определён
+	Confidence float64 // Уверенность от 0 до 1
+}
+
+// profile хранит частоты n-грамм одного языка
+type profile struct {
+	script string
+	counts map[string]int
+	total  int
+}
+
+// Detector определяет язык текста по встроенным профилям
+type Detector struct {
+	profiles map[string]*profile
+	vocab    int
+}
+
+var (
+	defaultDetector *Detector
+	defaultOnce     sync.Once
+)
+
+// Default возвращает общий детектор со встроенными профилями
+func Default() *Detector {
+	defaultOnce.Do(func() {
+		defaultDetector = New()
+	})
+	return defaultDetector
+}
+
+// New строит профили языков из встроенных образцов текста
+func New() *Detector {
+	d := &Detector{profiles: make(map[string]*profile)}
+	vocab := make(map[string]bool)
+
+	files, err := corpus.ReadDir("corpus")
+	if err != nil {
+		panic("langdetect: embedded corpus is missing: " + err.Error())
+	}
+	for _, file := range files {
+		data, err := corpus.ReadFile(path.Join("corpus", file.Name()))
+		if err != nil {
+			panic("langdetect: failed to read embedded corpus: " + err.Error())
+		}
+
+		text := normalize(string(data))
+		p := &profile{script: dominantScript(text), counts: ngrams(text)}
+		for gram, count := range p.counts {
+			p.total += count
+			vocab[gram] = true
+		}
+		d.profiles[strings.TrimSuffix(file.Name(), ".txt")] = p
+	}
+
+	d.vocab = len(vocab)
+	return d
+}
+
+// Languages возвращает коды поддерживаемых языков
+func (d *Detector) Languages() []string {
+	langs := make([]string, 0, len(d.profiles))
+	for lang := range d.profiles {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Detect определяет язык текста. Сначала отбираются языки с той же письменностью,
+// затем среди них выбирается наиболее вероятный по наивному байесовскому классификатору
+func (d *Detector) Detect(text string) Result {
+	text = normalize(text)
+	if countLetters(text) < minLetters {
+		return Result{}
+	}
+
+	script := dominantScript(text)
+	grams := ngrams(text)
+
+	total := 0
+	for _, count := range grams {
+		total += count
+	}
+
+	scores := make(map[string]float64)
+	for lang, p := range d.profiles {
+		if p.script != script {
+			continue
+		}
+		score := 0.0
+		for gram, count := range grams {
+			score += float64(count) * math.Log(float64(p.counts[gram]+1)/float64(p.total+d.vocab))
+		}
+		// Сумма логарифмов растёт с длиной текста, и softmax по ней для любого куплета давал бы 1,
+		// поэтому уверенность считается по среднему логарифму правдоподобия на n-грамму
+		scores[lang] = confidenceSharpness * score / float64(total)
+	}
+	if len(scores) == 0 {
+		return Result{}
+	}
+
+	// Средние логарифмы правдоподобия переводятся в вероятности через softmax
+	best, bestScore := "", math.Inf(-1)
+	for lang, score := range scores {
+		if score > bestScore || (score == bestScore && lang < best) {
+			best, bestScore = lang, score
+		}
+	}
+	sum := 0.0
+	for _, score := range scores {
+		sum += math.Exp(score - bestScore)
+	}
+
+	return Result{Lang: best, Confidence: math.Round(100/sum) / 100}
+}
+
+// normalize приводит текст к нижнему регистру, заменяет экранированные переводы
+// строк и всё, кроме букв, на пробелы
+func normalize(text string) string {
+	text = strings.ReplaceAll(text, `\n`, " ")
+	var b strings.Builder
+	b.Grow(len(text))
+	space := true
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || r == '\'' || r == '’' {
+			b.WriteRune(r)
+			space = false
+		} else if !space {
+			b.WriteRune(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// ngrams считает n-граммы длиной от 1 до maxNgram; границы слов отмечаются пробелом
+func ngrams(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, word := range strings.Fields(text) {
+		runes := []rune(" " + word + " ")
+		for n := 1; n <= maxNgram; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				gram := string(runes[i : i+n])
+				if gram == " " {
+					continue
+				}
+				counts[gram]++
+			}
+		}
+	}
+	return counts
+}
+
+// dominantScript возвращает преобладающую письменность текста
+func dominantScript(text string) string {
+	var cyrillic, latin int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	if cyrillic > latin {
+		return "cyrillic"
+	}
+	return "latin"
+}
+
+// countLetters возвращает число букв в тексте
+func countLetters(text string) int {
+	n := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			n++
+		}
+	}
+	return n
+}
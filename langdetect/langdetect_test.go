@@ -0,0 +1,96 @@
+package langdetect
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	d := Default()
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "english", text: "I have been waiting for you all night long, and the rain keeps falling on the window", want: "en"},
+		{name: "russian", text: "Я ждал тебя всю ночь, а дождь всё стучит в окно и не даёт уснуть", want: "ru"},
+		{name: "ukrainian", text: "Я чекав на тебе всю ніч, а дощ усе стукає у вікно і не дає заснути", want: "uk"},
+		{name: "german", text: "Ich habe die ganze Nacht auf dich gewartet, und der Regen fällt immer noch gegen das Fenster", want: "de"},
+		{name: "french", text: "Je t'ai attendu toute la nuit, et la pluie continue de tomber sur la fenêtre", want: "fr"},
+		{name: "spanish", text: "Te esperé toda la noche, y la lluvia sigue cayendo sobre la ventana", want: "es"},
+		{name: "escaped newlines", text: `Я ждал тебя всю ночь\nА дождь всё стучит в окно`, want: "ru"},
+		{name: "too short", text: "Hey you"},
+		{name: "no letters", text: "1234567890 !!! ... 42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := d.Detect(tt.text)
+			if got.Lang != tt.want {
+				t.Fatalf("Detect(%q) = %+v, want %q", tt.text, got, tt.want)
+			}
+			if tt.want == "" && got.Confidence != 0 {
+				t.Errorf("undetected language has confidence %v", got.Confidence)
+			}
+			if tt.want != "" && (got.Confidence <= 0 || got.Confidence > 1) {
+				t.Errorf("confidence %v out of range", got.Confidence)
+			}
+		})
+	}
+}
+
+func TestDetectConfidence(t *testing.T) {
+	d := Default()
+	clear := "I have been waiting for you all night long, and the rain keeps falling on the window"
+
+	single := d.Detect(clear)
+	if single.Confidence < 0.9 {
+		t.Errorf("confidence of unambiguous text = %v, want at least 0.9", single.Confidence)
+	}
+
+	// Длина текста не должна доводить уверенность до 1
+	repeated := d.Detect(strings.Repeat(clear+`\n`, 20))
+	if repeated.Lang != "en" || repeated.Confidence >= 1 {
+		t.Errorf("Detect(long text) = %+v, want en with confidence below 1", repeated)
+	}
+
+	tests := []struct {
+		name string
+		text string
+	}{
+		{name: "english and french", text: "I love you mon amour, je t'aime baby tonight"},
+		{name: "three languages", text: "Hola amigo, hello my friend, wie geht es dir"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.Detect(tt.text); got.Confidence > 0.9 || got.Confidence <= 0 {
+				t.Errorf("Detect(%q) = %+v, want confidence clearly below 1", tt.text, got)
+			}
+		})
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	want := []string{"de", "en", "es", "fr", "ru", "uk"}
+	if got := Default().Languages(); !slices.Equal(got, want) {
+		t.Errorf("Languages() = %v, want %v", got, want)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "Hello, World!", want: "hello world"},
+		{text: `line\nnext`, want: "line next"},
+		{text: "  don't   stop 123 ", want: "don't stop"},
+		{text: "...", want: ""},
+	}
+	for _, tt := range tests {
+		if got := normalize(tt.text); got != tt.want {
+			t.Errorf("normalize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
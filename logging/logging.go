@@ -0,0 +1,102 @@
+// Package logging настраивает структурированный журнал log/slog: уровень и формат берутся
+// из конфигурации, идентификаторы запроса и трассы передаются через context и добавляются к каждой записи,
+// а секреты и длинные значения (например, тексты песен) скрываются или обрезаются.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"log/slog"
+	"song-libary/config"
+	"strings"
+	"unicode/utf8"
+)
+
+// Параметры скрытия данных в журнале
+const (
+	redacted    = "[REDACTED]"
+	maxValueLen = 256 // Строки длиннее обрезаются до этого числа символов
+)
+
+// secretKeys перечисляет ключи атрибутов, значения которых никогда не пишутся в журнал
+var secretKeys = map[string]bool{
+	"password":      true,
+	"dsn":           true,
+	"token":         true,
+	"secret":        true,
+	"api_key":       true,
+	"authorization": true,
+}
+
+// New создаёт логгер с уровнем и форматом из cfg, пишущий в w
+func New(cfg config.LogConfig, w io.Writer) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+
+	options := &slog.HandlerOptions{Level: level, ReplaceAttr: redact}
+	var handler slog.Handler
+	switch cfg.Format {
+	case "json":
+		handler = slog.NewJSONHandler(w, options)
+	case "text":
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q", cfg.Format)
+	}
+	return slog.New(contextHandler{handler}), nil
+}
+
+// redact скрывает секреты и обрезает длинные строковые значения
+func redact(_ []string, attr slog.Attr) slog.Attr {
+	if secretKeys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, redacted)
+	}
+	if attr.Value.Kind() == slog.KindString {
+		if value := attr.Value.String(); utf8.RuneCountInString(value) > maxValueLen {
+			runes := []rune(value)
+			return slog.String(attr.Key, fmt.Sprintf("%s…(%d chars)", string(runes[:maxValueLen]), len(runes)))
+		}
+	}
+	return attr
+}
+
+// requestIDKey — ключ идентификатора запроса в context
+type requestIDKey struct{}
+
+// WithRequestID возвращает context с идентификатором запроса
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID возвращает идентификатор запроса из context или пустую строку
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// contextHandler добавляет к записи идентификатор запроса и идентификаторы трассы из context
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"song-libary/config"
+	"song-libary/models"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRedact(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(config.LogConfig{Level: "info", Format: "json"}, &buf)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	lyrics := strings.Repeat("я", maxValueLen+44)
+	logger.Info("test",
+		"password", "hunter2",
+		"DSN", "postgres://user:pass@db/songs",
+		"short", "hello",
+		"text", lyrics,
+		"count", 3,
+		"params", models.FilterParams{Group: "Muse", Text: lyrics, Limit: 10},
+	)
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("decode record: %v", err)
+	}
+	if record["password"] != redacted || record["DSN"] != redacted {
+		t.Errorf("secrets = %v, %v, want %q", record["password"], record["DSN"], redacted)
+	}
+	if record["short"] != "hello" || record["count"] != float64(3) {
+		t.Errorf("short = %v, count = %v, want unchanged", record["short"], record["count"])
+	}
+
+	wantText := strings.Repeat("я", maxValueLen) + "…(300 chars)"
+	if record["text"] != wantText {
+		t.Errorf("text has %d chars, want truncated to %q", utf8.RuneCountInString(record["text"].(string)), wantText)
+	}
+
+	params, ok := record["params"].(map[string]any)
+	if !ok {
+		t.Fatalf("params = %v, want a group", record["params"])
+	}
+	if params["text"] != wantText {
+		t.Errorf("params.text was not truncated")
+	}
+	if params["group"] != "Muse" || params["limit"] != float64(10) {
+		t.Errorf("params = %v, want group and limit", params)
+	}
+	if _, ok := params["song"]; ok {
+		t.Errorf("params = %v, want unset fields omitted", params)
+	}
+}
@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader — заголовок, в котором клиент может передать идентификатор запроса
+// и в котором сервер его возвращает
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen ограничивает длину идентификатора, принятого от клиента
+const maxRequestIDLen = 128
+
+// Middleware присваивает запросу идентификатор: берёт его из заголовка X-Request-ID
+// или генерирует новый, кладёт в context запроса и возвращает в ответе
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+	})
+}
+
+// validRequestID допускает непустые идентификаторы разумной длины из печатных ASCII-символов
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID генерирует случайный идентификатор из 16 байт в шестнадцатеричной записи
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
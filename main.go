@@ -1,62 +1,247 @@
 package main
 
 import (
-	"github.com/joho/godotenv"
+	"context"
+	"errors"
+	"fmt"
 	"github.com/swaggo/http-swagger/v2"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"song-libary/config"
 	"song-libary/db"
 	_ "song-libary/docs"
 	handlers "song-libary/hendlers"
+	"song-libary/logging"
+	"song-libary/metrics"
+	"song-libary/profanity"
+	"song-libary/ratelimit"
 	"song-libary/repository"
+	"song-libary/router"
 	"song-libary/service"
+	"song-libary/tracing"
+	"sync"
+	"syscall"
+	"time"
 )
 
 func main() {
-	log.Println("[INFO] Loading environment variables...")
-	if err := godotenv.Load(); err != nil {
-		log.Fatalf("[ERROR] Failed to load .env file: %v", err)
-	}
-
-	// Загрузка переменных окружения
-	host := os.Getenv("DB_HOST")
-	port := os.Getenv("DB_PORT")
-	user := os.Getenv("DB_USER")
-	password := os.Getenv("DB_PASSWORD")
-	dbname := os.Getenv("DB_NAME")
-	migrationsPath := "./db/migrations"
-
-	dbManager := db.NewDbManager()
-
-	log.Println("[INFO] Initializing database connection...")
-	if err := dbManager.InitDB(host, port, user, password, dbname); err != nil {
-		log.Fatalf("[ERROR] Failed to initialize database: %v", err)
-	}
-	log.Printf("[INFO] Connected to database at %s:%s as user %s", host, port, user)
-
-	log.Println("[INFO] Applying migrations...")
-	if err := dbManager.ApplyMigrations(migrationsPath); err != nil {
-		log.Fatalf("[ERROR] Failed to apply migrations: %v", err)
-	}
-
-	log.Println("[INFO] Setting up repositories, services, and handlers...")
-	songRepo := repository.NewSongRepositorySqlDbImpl(dbManager.DB)
-	songService := service.NewSongService(songRepo)
-	songHandler := handlers.NewSongHandler(songService)
-
-	log.Println("[INFO] Registering routes...")
-	// Swagger UI доступен по адресу /swagger/index.html
-	http.Handle("/swagger/", http.StripPrefix("/swagger", httpSwagger.WrapHandler))
-	http.HandleFunc("/songs", songHandler.GetSongsHandler)
-	http.HandleFunc("/songs/info", songHandler.InfoHandler)
-	http.HandleFunc("/songs/add", songHandler.AddSongHandler)
-	http.HandleFunc("/songs/delete", songHandler.DeleteSongHandler)
-	http.HandleFunc("/songs/update", songHandler.UpdateSongHandler)
-	http.HandleFunc("/songs/text", songHandler.GetSongTextHandler)
-
-	log.Println("[INFO] Starting server on port 8080...")
-	if err := http.ListenAndServe(":8080", nil); err != nil {
-		log.Fatalf("[ERROR] Server failed: %v", err)
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, config.ErrPrintConfig) {
+		fmt.Print(cfg)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	logger, err := logging.New(cfg.Log, os.Stderr)
+	if err != nil {
+		slog.Error("Failed to create logger", "error", err)
+		os.Exit(1)
+	}
+	// Стандартный логгер (в том числе сообщения goose) пишет через тот же обработчик
+	slog.SetDefault(logger)
+	logger.Info("Configuration loaded", "config", cfg.Redacted())
+
+	// ctx отменяется по SIGINT/SIGTERM: прерывает подготовку к запуску и начинает остановку сервера
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := tracing.New(ctx, cfg.Tracing, os.Stdout)
+	if err != nil {
+		logger.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	dbManager := db.NewDbManager(logger)
+
+	logger.Info("Initializing database connection")
+	if err := dbManager.InitDB(ctx, cfg.Database); err != nil {
+		logger.Error("Failed to initialize database", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("Connected to database", "host", cfg.Database.Host, "port", cfg.Database.Port, "user", cfg.Database.User)
+
+	logger.Info("Applying migrations")
+	if err := dbManager.ApplyMigrations(ctx, cfg.Database.MigrationsDir); err != nil {
+		logger.Error("Failed to apply migrations", "error", err)
+		os.Exit(1)
+	}
+
+	if err := metrics.RegisterDB(dbManager.DB, cfg.Database.Name); err != nil {
+		logger.Error("Failed to register database metrics", "error", err)
+		os.Exit(1)
+	}
+
+	logger.Info("Setting up repositories, services, and handlers")
+	songRepo := repository.NewSongRepositorySqlDbImpl(dbManager.DB, logger)
+	songService := service.NewSongService(songRepo, logger)
+	translationRepo := repository.NewTranslationRepositorySqlDbImpl(dbManager.DB, logger)
+	translationService := service.NewTranslationService(translationRepo, songRepo, logger)
+
+	// Собственные списки ненормативной лексики заменяют встроенные
+	if dir := cfg.Profanity.WordlistsDir; dir != "" {
+		filter, err := profanity.LoadDir(dir)
+		if err != nil {
+			logger.Error("Failed to load profanity word lists", "error", err)
+			os.Exit(1)
+		}
+		songService.Profanity = filter
+		translationService.Profanity = filter
+	}
+
+	translationHandler := handlers.NewTranslationHandler(translationService, logger)
+	songHandler := handlers.NewSongHandler(songService, translationService, logger)
+	tagRepo := repository.NewTagRepositorySqlDbImpl(dbManager.DB, logger)
+	tagService := service.NewTagService(tagRepo, logger)
+	tagHandler := handlers.NewTagHandler(tagService, logger)
+	creditRepo := repository.NewCreditRepositorySqlDbImpl(dbManager.DB, logger)
+	creditService := service.NewCreditService(creditRepo, logger)
+	creditHandler := handlers.NewCreditHandler(creditService, logger)
+	linkRepo := repository.NewLinkRepositorySqlDbImpl(dbManager.DB, logger)
+	linkService := service.NewLinkService(linkRepo, logger)
+	linkHandler := handlers.NewLinkHandler(linkService, logger)
+	duplicateRepo := repository.NewDuplicateRepositorySqlDbImpl(dbManager.DB, logger)
+	duplicateService := service.NewDuplicateService(duplicateRepo, songRepo, songService.Similarity, logger)
+	duplicateHandler := handlers.NewDuplicateHandler(duplicateService, logger)
+	statsRepo := repository.NewStatsRepositorySqlDbImpl(dbManager.DB, logger)
+	statsService := service.NewStatsService(statsRepo, logger)
+	statsHandler := handlers.NewStatsHandler(statsService, logger)
+	healthService := service.NewHealthService(logger,
+		service.HealthCheck{Name: "database", Check: dbManager.DB.PingContext},
+		service.HealthCheck{Name: "migrations", Check: func(ctx context.Context) error {
+			pending, err := dbManager.PendingMigrations(ctx, cfg.Database.MigrationsDir)
+			if err == nil && pending > 0 {
+				err = fmt.Errorf("%d migrations pending", pending)
+			}
+			return err
+		}},
+	)
+	healthHandler := handlers.NewHealthHandler(healthService, logger)
+
+	var rateLimitStore ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RateLimit.Store == "postgres" {
+		rateLimitStore = ratelimit.NewPostgresStore(dbManager.DB, logger)
+	}
+	limiter := ratelimit.NewLimiter(rateLimitStore, cfg.RateLimit, logger)
+
+	if cfg.Features.SimilarSongs {
+		if err := songService.BuildSimilarityIndex(ctx); err != nil {
+			logger.Error("Failed to build similarity index", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Фоновые задачи останавливаются отменой workersCtx после остановки HTTP-сервера
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	if cfg.Features.LinkChecker {
+		logger.Info("Starting background link checker")
+		linkCheckerConfig := service.LinkCheckerConfig(cfg.LinkChecker)
+		linkCheckerClient := service.NewLinkCheckerClient(linkCheckerConfig.Timeout)
+		linkChecker := service.NewLinkChecker(linkRepo, linkCheckerClient, time.Now, linkCheckerConfig, logger)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			linkChecker.Run(workersCtx)
+		}()
+	}
+
+	logger.Info("Registering routes")
+	routes := router.New(handlers.NotFound(logger), handlers.MethodNotAllowed(logger))
+	routes.Get("/healthz", healthHandler.LivenessHandler)
+	routes.Get("/readyz", healthHandler.ReadinessHandler)
+	routes.Handle(http.MethodGet, "/metrics", metrics.Handler())
+	if cfg.Features.Swagger {
+		// Swagger UI доступен по адресу /swagger/index.html
+		routes.Mount("/swagger/", http.StripPrefix("/swagger", httpSwagger.WrapHandler))
+	}
+
+	// Маршруты API ограничены по частоте запросов клиента и выполняются с дедлайном из настроек timeouts
+	api := routes.Group("")
+	api.Use(handlers.RateLimit(limiter), handlers.Timeout(cfg.Timeouts.For))
+
+	songs := api.Group("/songs")
+	songs.Get("", songHandler.GetSongsHandler)
+	songs.Get("/info", songHandler.InfoHandler)
+	songs.Post("/add", songHandler.AddSongHandler)
+	songs.Delete("/delete", songHandler.DeleteSongHandler)
+	songs.Put("/update", songHandler.UpdateSongHandler)
+	songs.Get("/text", songHandler.GetSongTextHandler)
+	songs.Get("/on-this-day", songHandler.GetSongsOnThisDayHandler)
+	songs.Get("/random", songHandler.GetRandomSongsHandler)
+	songs.Get("/{id}/stats", songHandler.GetSongStatsHandler)
+	if cfg.Features.SimilarSongs {
+		songs.Get("/{id}/similar", songHandler.GetSimilarSongsHandler)
+	}
+	songs.Post("/tags/add", tagHandler.TagSongHandler)
+	songs.Delete("/tags/delete", tagHandler.UntagSongHandler)
+	songs.Get("/credits", creditHandler.GetCreditsHandler)
+	songs.Put("/credits/update", creditHandler.SetCreditsHandler)
+	songs.Get("/links", linkHandler.GetLinksHandler)
+	songs.Post("/links/add", linkHandler.AddLinkHandler)
+	songs.Delete("/links/delete", linkHandler.DeleteLinkHandler)
+	songs.Get("/links/report", linkHandler.BrokenLinksReportHandler)
+	songs.Get("/translations", translationHandler.GetTranslationsHandler)
+	songs.Post("/translations/add", translationHandler.AddTranslationHandler)
+	songs.Put("/translations/update", translationHandler.UpdateTranslationHandler)
+	songs.Delete("/translations/delete", translationHandler.DeleteTranslationHandler)
+	songs.Post("/merge", duplicateHandler.MergeSongsHandler)
+
+	api.Get("/groups/{group}/stats", songHandler.GetGroupStatsHandler)
+	api.Get("/duplicates", duplicateHandler.GetDuplicatesHandler)
+	api.Post("/duplicates/{id}/dismiss", duplicateHandler.DismissDuplicateHandler)
+	api.Get("/stats", statsHandler.GetStatsHandler)
+	api.Get("/stats/groups", statsHandler.GetGroupCountsHandler)
+	api.Get("/genres", tagHandler.GetGenresHandler)
+	api.Post("/genres/add", tagHandler.AddGenreHandler)
+
+	server := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           logging.Middleware(tracing.Middleware(metrics.Middleware(routes), "/healthz", "/readyz", "/metrics")),
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if cfg.Server.TLS.Enabled() {
+			logger.Info("Starting TLS server", "addr", cfg.Server.Addr)
+			serverErr <- server.ListenAndServeTLS(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+		} else {
+			logger.Info("Starting server", "addr", cfg.Server.Addr)
+			serverErr <- server.ListenAndServe()
+		}
+	}()
+
+	failed := false
+	select {
+	case err := <-serverErr:
+		logger.Error("Server failed", "error", err)
+		failed = true
+	case <-ctx.Done():
+		logger.Info("Shutdown signal received")
+	}
+	// Повторный сигнал завершает процесс сразу, не дожидаясь дедлайна
+	stop()
+
+	// Если сервер не запустился, ждать исключения из балансировки незачем
+	delay := cfg.Server.ShutdownDelay
+	if failed {
+		delay = 0
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), delay+cfg.Server.ShutdownTimeout)
+	defer cancel()
+	shutdown(shutdownCtx, logger, delay, server, healthService, stopWorkers, &workers, dbManager)
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("Failed to flush traces", "error", err)
+	}
+	if failed {
+		os.Exit(1)
 	}
 }
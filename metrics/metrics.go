@@ -0,0 +1,133 @@
+// Package metrics содержит метрики Prometheus для HTTP-запросов, запросов к базе данных
+// и событий предметной области. Метрики регистрируются в стандартном реестре и отдаются
+// обработчиком Handler.
+package metrics
+
+import (
+	"database/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"song-libary/internal/httpx"
+	"song-libary/router"
+	"strconv"
+	"time"
+)
+
+// namespace добавляется ко всем метрикам сервиса
+const namespace = "song_library"
+
+var (
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of repository methods by repository and method.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"repository", "method"})
+
+	// RateLimited считает отклонённые ограничителем частоты запросы по маршруту
+	RateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Number of requests rejected by the rate limiter by route.",
+	}, []string{"route"})
+
+	// SongsAdded считает добавленные песни
+	SongsAdded = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "songs_added_total",
+		Help:      "Number of songs added.",
+	})
+
+	// SongsUpdated считает изменённые песни
+	SongsUpdated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "songs_updated_total",
+		Help:      "Number of songs updated.",
+	})
+
+	// SongsDeleted считает удалённые песни
+	SongsDeleted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "songs_deleted_total",
+		Help:      "Number of songs deleted.",
+	})
+
+	// SongsMerged считает песни, объединённые с дубликатами
+	SongsMerged = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "songs_merged_total",
+		Help:      "Number of duplicate songs merged into another song.",
+	})
+)
+
+// httpMetrics — счётчик и гистограмма HTTP-запросов. Сервис пишет в стандартный реестр,
+// тесты — в собственный
+type httpMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// defaultHTTPMetrics зарегистрированы в стандартном реестре и используются Middleware
+var defaultHTTPMetrics = newHTTPMetrics(prometheus.DefaultRegisterer)
+
+func newHTTPMetrics(registerer prometheus.Registerer) *httpMetrics {
+	factory := promauto.With(registerer)
+	return &httpMetrics{
+		requests: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		duration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+	}
+}
+
+// Handler возвращает обработчик, отдающий метрики в формате Prometheus
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// RegisterDB добавляет метрики пула соединений sql.DBStats для базы данных dbName
+func RegisterDB(db *sql.DB, dbName string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// ObserveQuery записывает длительность метода репозитория, начавшегося в started.
+// Вызывается через defer в начале метода: defer metrics.ObserveQuery("song", "SaveSong", time.Now())
+func ObserveQuery(repository, method string, started time.Time) {
+	queryDuration.WithLabelValues(repository, method).Observe(time.Since(started).Seconds())
+}
+
+// Middleware считает HTTP-запросы и их длительность. В метку route попадает шаблон пути маршрута
+// (например, "/songs/{id}/stats"), а не путь запроса, чтобы число серий не росло с количеством песен
+func Middleware(next http.Handler) http.Handler {
+	return defaultHTTPMetrics.middleware(next)
+}
+
+func (m *httpMetrics) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+		recorder := httpx.NewStatusRecorder(w)
+		next.ServeHTTP(recorder, r)
+
+		route := router.Path(r)
+		if route == "" {
+			route = "unmatched"
+		}
+		labels := prometheus.Labels{
+			"method": r.Method,
+			"route":  route,
+			"status": strconv.Itoa(recorder.Status()),
+		}
+		m.requests.With(labels).Inc()
+		m.duration.With(labels).Observe(time.Since(started).Seconds())
+	})
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"net/http"
+	"net/http/httptest"
+	"song-libary/router"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	m := newHTTPMetrics(prometheus.NewRegistry())
+
+	rt := router.New(nil, nil)
+	rt.Get("/songs/{id}/stats", func(w http.ResponseWriter, r *http.Request) {})
+	rt.Post("/songs/add", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	handler := m.middleware(rt)
+
+	for _, request := range []struct{ method, target string }{
+		{http.MethodGet, "/songs/1/stats"},
+		{http.MethodGet, "/songs/2/stats"},
+		{http.MethodPost, "/songs/add"},
+		{http.MethodDelete, "/songs/1/stats"},
+		{http.MethodGet, "/missing"},
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(request.method, request.target, nil))
+	}
+
+	// Запросы к разным песням попадают в одну серию с шаблоном пути
+	tests := []struct {
+		method, route, status string
+		want                  float64
+	}{
+		{http.MethodGet, "/songs/{id}/stats", "200", 2},
+		{http.MethodPost, "/songs/add", "400", 1},
+		{http.MethodDelete, "/songs/{id}/stats", "405", 1},
+		{http.MethodGet, "unmatched", "404", 1},
+	}
+	for _, tt := range tests {
+		if got := testutil.ToFloat64(m.requests.WithLabelValues(tt.method, tt.route, tt.status)); got != tt.want {
+			t.Errorf("requests{%s %s %s} = %v, want %v", tt.method, tt.route, tt.status, got, tt.want)
+		}
+	}
+	if got := testutil.CollectAndCount(m.requests); got != len(tests) {
+		t.Errorf("request series = %d, want %d", got, len(tests))
+	}
+	if got := testutil.CollectAndCount(m.duration); got != len(tests) {
+		t.Errorf("duration series = %d, want %d", got, len(tests))
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+// Роли участников работы над песней
+const (
+	CreditRolePrimary  = "primary"  // Основной исполнитель
+	CreditRoleFeatured = "featured" // Приглашённый исполнитель
+	CreditRoleComposer = "composer" // Композитор
+	CreditRoleLyricist = "lyricist" // Автор текста
+	CreditRoleProducer = "producer" // Продюсер
+)
+
+// Credit представляет участника работы над песней и его роль
+type Credit struct {
+	Name string `json:"name" example:"Matthew Bellamy"`
+	Role string `json:"role" example:"composer" enums:"primary,featured,composer,lyricist,producer"`
+}
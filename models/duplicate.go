@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Статусы кандидатов в дубликаты
+const (
+	DuplicateStatusPending   = "pending"   // Ожидает проверки
+	DuplicateStatusDismissed = "dismissed" // Песни признаны разными
+)
+
+// Поля песни, которые при слиянии можно взять у дубликата
+const (
+	MergeFieldGroup       = "group"        // Название группы
+	MergeFieldSong        = "song"         // Название песни
+	MergeFieldText        = "text"         // Текст вместе с языком и признаком explicit
+	MergeFieldReleaseDate = "release_date" // Дата релиза
+	MergeFieldLink        = "link"         // Ссылка
+	MergeFieldDuration    = "duration_ms"  // Длительность
+	MergeFieldISRC        = "isrc"         // Международный код записи
+	MergeFieldBPM         = "bpm"          // Темп
+	MergeFieldKey         = "key"          // Тональность
+)
+
+// DuplicateCandidate представляет пару песен, похожих на дубликаты, для ручной проверки
+type DuplicateCandidate struct {
+	ID                int       `json:"id"`                  // Идентификатор кандидата
+	SongID            string    `json:"song_id"`             // UUID первой песни
+	Group             string    `json:"group"`               // Группа первой песни
+	SongName          string    `json:"song_name"`           // Название первой песни
+	DuplicateID       string    `json:"duplicate_id"`        // UUID второй песни
+	DuplicateGroup    string    `json:"duplicate_group"`     // Группа второй песни
+	DuplicateSongName string    `json:"duplicate_song_name"` // Название второй песни
+	Similarity        float64   `json:"similarity"`          // Близость текстов от 0 до 1
+	Status            string    `json:"status"`              // pending или dismissed
+	CreatedAt         time.Time `json:"created_at"`          // Время обнаружения
+}
@@ -0,0 +1,21 @@
+package models
+
+// Статусы проверок состояния сервиса
+const (
+	HealthStatusOK   = "ok"
+	HealthStatusFail = "fail"
+)
+
+// HealthResponse представляет результат проверки состояния сервиса
+type HealthResponse struct {
+	Status string        `json:"status"`           // ok, если все проверки прошли, иначе fail
+	Checks []HealthCheck `json:"checks,omitempty"` // Результаты отдельных проверок
+}
+
+// HealthCheck представляет результат проверки одной зависимости
+type HealthCheck struct {
+	Name      string  `json:"name"`            // Название зависимости
+	Status    string  `json:"status"`          // ok или fail
+	LatencyMs float64 `json:"latency_ms"`      // Время проверки в миллисекундах
+	Error     string  `json:"error,omitempty"` // Причина ошибки
+}
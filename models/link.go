@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Провайдеры ссылок на песню
+const (
+	LinkProviderYouTube    = "youtube"
+	LinkProviderSpotify    = "spotify"
+	LinkProviderAppleMusic = "apple_music"
+	LinkProviderBandcamp   = "bandcamp"
+	LinkProviderOther      = "other"
+)
+
+// Состояния ссылки по результатам фоновой проверки
+const (
+	LinkStatusUnchecked = "unchecked" // Ссылка ещё не проверялась
+	LinkStatusOK        = "ok"        // Последняя проверка прошла успешно
+	LinkStatusBroken    = "broken"    // Ссылка не отвечает несколько проверок подряд
+)
+
+// SongLink представляет ссылку на песню в стриминговом сервисе
+type SongLink struct {
+	ID            int        `json:"id"`
+	Provider      string     `json:"provider" example:"youtube"`                 // Провайдер, определяется автоматически
+	URL           string     `json:"url" example:"https://youtu.be/3dm_5qWWDV8"` // Исходная ссылка
+	ExternalID    string     `json:"external_id" example:"3dm_5qWWDV8"`          // Идентификатор трека у провайдера
+	Region        string     `json:"region" example:"US"`                        // Регион доступности (ISO 3166-1 alpha-2)
+	Status        string     `json:"status" example:"ok"`                        // Результат проверки: unchecked, ok или broken
+	StatusCode    *int       `json:"status_code,omitempty" example:"200"`        // HTTP-статус последней проверки
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"`                  // Время последней проверки
+	FailureStreak int        `json:"failure_streak"`                             // Число неудачных проверок подряд
+}
+
+// BrokenLink представляет строку отчёта о нерабочих ссылках
+type BrokenLink struct {
+	GroupName string `json:"group_name"`
+	SongName  string `json:"song_name"`
+	SongLink
+}
@@ -0,0 +1,20 @@
+package models
+
+// Problem представляет ошибку в формате application/problem+json (RFC 7807)
+type Problem struct {
+	Type      string       `json:"type"`                 // URI типа ошибки, например urn:song-library:problem:song_not_found
+	Title     string       `json:"title"`                // Краткое описание HTTP-статуса
+	Status    int          `json:"status"`               // HTTP-статус
+	Detail    string       `json:"detail,omitempty"`     // Описание конкретной ошибки
+	Instance  string       `json:"instance,omitempty"`   // Путь запроса
+	Code      string       `json:"code"`                 // Машинно-читаемый код ошибки
+	RequestID string       `json:"request_id,omitempty"` // Идентификатор запроса для поиска в журнале
+	Errors    []FieldError `json:"errors,omitempty"`     // Ошибки отдельных полей запроса
+}
+
+// FieldError описывает ошибку в одном поле запроса
+type FieldError struct {
+	Field   string `json:"field"`   // Имя поля или параметра запроса, например isrc
+	Code    string `json:"code"`    // Машинно-читаемый код, например required или invalid_format
+	Message string `json:"message"` // Описание ошибки
+}
@@ -1,31 +1,175 @@
 package models
 
+import (
+	"log/slog"
+	"strings"
+)
+
 // AddSongRequest представляет тело запроса для добавления новой песни
 type AddSongRequest struct {
-	Group       string `json:"group"`        // Название группы
-	Song        string `json:"song"`         // Название песни
-	Text        string `json:"text"`         // Текст песни
-	ReleaseDate string `json:"release_date"` // Дата релиза
-	Link        string `json:"link"`         // ссылка на песню
+	Group       string `json:"group" validate:"required,max=100"` // Название группы
+	Song        string `json:"song" validate:"required,max=100"`  // Название песни
+	Text        string `json:"text"`                              // Текст песни
+	ReleaseDate string `json:"release_date" validate:"date"`      // Дата релиза
+	Link        string `json:"link" validate:"link"`              // ссылка на песню
+	DurationMs  *int   `json:"duration_ms" validate:"min=1"`      // Длительность в миллисекундах
+	ISRC        string `json:"isrc" validate:"isrc"`              // Международный код записи
+	BPM         *int   `json:"bpm" validate:"min=1,max=999"`      // Темп, ударов в минуту
+	Key         string `json:"key" validate:"key"`                // Тональность, например C#m
+	Explicit    bool   `json:"explicit"`                          // Ненормативное содержание
+	Language    string `json:"language" validate:"lang"`          // Язык текста; если не указан, определяется автоматически
 }
 
 // UpdateSongRequest представляет тело запроса для изменения данных песни
 type UpdateSongRequest struct {
-	OldSongName    string `json:"old_song_name"`    // Название песни, которую нужно обновить
-	OldGroup       string `json:"old_group"`        // Старое название группы
-	NewGroup       string `json:"new_group"`        // Новое название группы
-	NewSongName    string `json:"new_song_name"`    // Новое название песни
-	NewText        string `json:"new_text"`         // Новый текст песни
-	NewReleaseDate string `json:"new_release_date"` // Дата релиза
-	NewLink        string `json:"new_link"`         // ссылка на песню
+	OldSongName    string `json:"old_song_name" validate:"required,max=100"` // Название песни, которую нужно обновить
+	OldGroup       string `json:"old_group" validate:"required,max=100"`     // Старое название группы
+	NewGroup       string `json:"new_group" validate:"required,max=100"`     // Новое название группы
+	NewSongName    string `json:"new_song_name" validate:"required,max=100"` // Новое название песни
+	NewText        string `json:"new_text"`                                  // Новый текст песни
+	NewReleaseDate string `json:"new_release_date" validate:"date"`          // Дата релиза
+	NewLink        string `json:"new_link" validate:"link"`                  // ссылка на песню
+	NewDurationMs  *int   `json:"new_duration_ms" validate:"min=1"`          // Длительность в миллисекундах
+	NewISRC        string `json:"new_isrc" validate:"isrc"`                  // Международный код записи
+	NewBPM         *int   `json:"new_bpm" validate:"min=1,max=999"`          // Темп, ударов в минуту
+	NewKey         string `json:"new_key" validate:"key"`                    // Тональность, например C#m
+	NewExplicit    bool   `json:"new_explicit"`                              // Ненормативное содержание
+	NewLanguage    string `json:"new_language" validate:"lang"`              // Язык текста; если не указан, определяется автоматически
 }
 
 // FilterParams представляет параметры фильтрации и пагинации
 type FilterParams struct {
-	Group       string `json:"group"`        // Название группы
-	SongName    string `json:"song"`         // Название песни
-	Text        string `json:"text"`         // Текст песни (поиск по включению)
-	ReleaseDate string `json:"release_date"` // Дата релиза
-	Limit       int    `json:"limit"`        // Количество записей на страницу
-	Offset      int    `json:"offset"`       // Смещение для пагинации
+	Group       string   `json:"group" validate:"max=100"`       // Название группы
+	SongName    string   `json:"song" validate:"max=100"`        // Название песни
+	Text        string   `json:"text"`                           // Текст песни (поиск по включению)
+	ReleaseDate string   `json:"release_date" validate:"date"`   // Дата релиза
+	Genre       string   `json:"genre" validate:"max=100"`       // Жанр (включая поджанры)
+	Tags        []string `json:"tags"`                           // Пользовательские теги
+	TagsMatch   string   `json:"tags_match"`                     // Режим совпадения тегов: any или all
+	Credited    string   `json:"credited" validate:"max=100"`    // Имя участника работы над песней
+	BPMMin      *int     `json:"bpm_min"`                        // Минимальный темп
+	BPMMax      *int     `json:"bpm_max"`                        // Максимальный темп
+	DurationMin *int     `json:"duration_min"`                   // Минимальная длительность, мс
+	DurationMax *int     `json:"duration_max"`                   // Максимальная длительность, мс
+	Key         string   `json:"key" validate:"key"`             // Тональность
+	LinkStatus  string   `json:"link_status"`                    // Состояние ссылок: unchecked, ok или broken
+	Language    string   `json:"language"`                       // Язык текста
+	NoExplicit  bool     `json:"no_explicit"`                    // Исключить песни с ненормативной лексикой
+	Limit       int      `json:"limit" validate:"min=1,max=100"` // Количество записей на страницу
+	Offset      int      `json:"offset" validate:"min=0"`        // Смещение для пагинации
+}
+
+// LogValue представляет параметры в журнале группой только заданных полей. Каждое поле пишется
+// отдельным атрибутом, поэтому длинный текст поиска text обрезается при записи, как любое длинное значение
+func (p FilterParams) LogValue() slog.Value {
+	var attrs []slog.Attr
+	addString := func(key, value string) {
+		if value != "" {
+			attrs = append(attrs, slog.String(key, value))
+		}
+	}
+	addInt := func(key string, value *int) {
+		if value != nil {
+			attrs = append(attrs, slog.Int(key, *value))
+		}
+	}
+
+	addString("group", p.Group)
+	addString("song", p.SongName)
+	addString("text", p.Text)
+	addString("release_date", p.ReleaseDate)
+	addString("genre", p.Genre)
+	addString("tags", strings.Join(p.Tags, ","))
+	addString("tags_match", p.TagsMatch)
+	addString("credited", p.Credited)
+	addInt("bpm_min", p.BPMMin)
+	addInt("bpm_max", p.BPMMax)
+	addInt("duration_min", p.DurationMin)
+	addInt("duration_max", p.DurationMax)
+	addString("key", p.Key)
+	addString("link_status", p.LinkStatus)
+	addString("language", p.Language)
+	if p.NoExplicit {
+		attrs = append(attrs, slog.Bool("no_explicit", true))
+	}
+	attrs = append(attrs, slog.Int("limit", p.Limit), slog.Int("offset", p.Offset))
+	return slog.GroupValue(attrs...)
+}
+
+// Режимы совпадения тегов в FilterParams
+const (
+	TagsMatchAny = "any" // Песня содержит хотя бы один из тегов
+	TagsMatchAll = "all" // Песня содержит все теги
+)
+
+// PageParams представляет параметры пагинации списков
+type PageParams struct {
+	Limit  int `json:"limit" validate:"min=1,max=100"` // Количество записей на страницу
+	Offset int `json:"offset" validate:"min=0"`        // Смещение для пагинации
+}
+
+// SongTextParams задаёт пагинацию и режим отображения текста песни
+type SongTextParams struct {
+	Limit    int    // Количество куплетов на страницу
+	Offset   int    // Смещение
+	Lang     string // Язык перевода; пустая строка означает оригинал
+	Parallel bool   // Вернуть пары куплетов оригинала и перевода
+	Clean    bool   // Замаскировать ненормативную лексику
+	Rhymes   bool   // Добавить схемы рифмовки куплетов
+}
+
+// AddGenreRequest представляет тело запроса для добавления жанра
+type AddGenreRequest struct {
+	Name   string `json:"name"`   // Название жанра
+	Parent string `json:"parent"` // Название родительского жанра (необязательно)
+}
+
+// TagSongRequest представляет тело запроса для добавления жанров и тегов к песне
+type TagSongRequest struct {
+	Group  string   `json:"group"`  // Название группы
+	Song   string   `json:"song"`   // Название песни
+	Genres []string `json:"genres"` // Жанры из справочника
+	Tags   []string `json:"tags"`   // Произвольные теги
+}
+
+// SetCreditsRequest представляет тело запроса для замены списка участников работы над песней
+type SetCreditsRequest struct {
+	Group   string   `json:"group"`   // Название группы
+	Song    string   `json:"song"`    // Название песни
+	Credits []Credit `json:"credits"` // Полный список участников с ролями
+}
+
+// AddSongLinkRequest представляет тело запроса для добавления ссылки на песню
+type AddSongLinkRequest struct {
+	Group  string `json:"group"`  // Название группы
+	Song   string `json:"song"`   // Название песни
+	URL    string `json:"url"`    // Ссылка на трек
+	Region string `json:"region"` // Регион доступности (необязательно)
+}
+
+// AddTranslationRequest представляет тело запроса для добавления перевода
+type AddTranslationRequest struct {
+	Group      string `json:"group"`      // Название группы
+	Song       string `json:"song"`       // Название песни
+	Lang       string `json:"lang"`       // Код языка перевода
+	Text       string `json:"text"`       // Переведённый текст
+	Translator string `json:"translator"` // Автор перевода
+	Status     string `json:"status"`     // Статус перевода (по умолчанию draft)
+}
+
+// UpdateTranslationRequest представляет тело запроса для изменения перевода
+type UpdateTranslationRequest struct {
+	Group         string `json:"group"`          // Название группы
+	Song          string `json:"song"`           // Название песни
+	Lang          string `json:"lang"`           // Код языка перевода
+	NewText       string `json:"new_text"`       // Новый текст перевода
+	NewTranslator string `json:"new_translator"` // Автор перевода
+	NewStatus     string `json:"new_status"`     // Новый статус перевода
+}
+
+// MergeSongsRequest представляет тело запроса на слияние двух песен
+type MergeSongsRequest struct {
+	SurvivorID  string   `json:"survivor_id"`  // UUID песни, которая остаётся
+	DuplicateID string   `json:"duplicate_id"` // UUID песни, которая удаляется
+	Fields      []string `json:"fields"`       // Поля, значения которых берутся у дубликата
 }
@@ -8,17 +8,46 @@ type DefaultResponse struct {
 
 // SongTextResponse представляет ответ с текстом песни
 type SongTextResponse struct {
-	SongName string   `json:"song_name"` // Название песни
-	Group    string   `json:"group"`     // Название группы
-	Verses   []string `json:"verses"`    // Список куплетов
-	Limit    int      `json:"limit"`     // Количество куплетов на страницу
-	Offset   int      `json:"offset"`    // Смещение
-	Total    int      `json:"total"`     // Общее количество куплетов
+	SongName string      `json:"song_name"`        // Название песни
+	Group    string      `json:"group"`            // Название группы
+	Lang     string      `json:"lang,omitempty"`   // Язык перевода, если запрошен
+	Verses   []string    `json:"verses"`           // Список куплетов
+	Pairs    []VersePair `json:"pairs,omitempty"`  // Пары куплетов оригинала и перевода в режиме parallel
+	Rhymes   []string    `json:"rhymes,omitempty"` // Схемы рифмовки куплетов (AABB, ABAB), если запрошены
+	Limit    int         `json:"limit"`            // Количество куплетов на страницу
+	Offset   int         `json:"offset"`           // Смещение
+	Total    int         `json:"total"`            // Общее количество куплетов
+}
+
+// VersePair представляет куплет оригинала рядом с его переводом
+type VersePair struct {
+	Original    string `json:"original"`    // Куплет оригинала
+	Translation string `json:"translation"` // Соответствующий куплет перевода
 }
 
 // SongDetail представляет информацию о песне
 type SongDetail struct {
-	ReleaseDate string `json:"release_date"` // Дата релиза
-	Text        string `json:"text"`         // Текст песни
-	Link        string `json:"link"`         // Ссылка на песню (например, на YouTube)
+	ReleaseDate        string   `json:"release_date"`          // Дата релиза
+	Text               string   `json:"text"`                  // Текст песни
+	Link               string   `json:"link"`                  // Ссылка на песню (например, на YouTube)
+	Genres             []string `json:"genres"`                // Жанры песни
+	Tags               []string `json:"tags"`                  // Теги песни
+	Credits            []Credit `json:"credits"`               // Участники работы над песней
+	DurationMs         *int     `json:"duration_ms,omitempty"` // Длительность в миллисекундах
+	ISRC               string   `json:"isrc"`                  // Международный код записи
+	BPM                *int     `json:"bpm,omitempty"`         // Темп, ударов в минуту
+	Key                string   `json:"key"`                   // Тональность
+	Explicit           bool     `json:"explicit"`              // Ненормативное содержание
+	ExplicitLines      []int64  `json:"explicit_lines"`        // Номера строк с ненормативной лексикой
+	Language           string   `json:"language"`              // Язык текста
+	LanguageConfidence float64  `json:"language_confidence"`   // Уверенность определения языка
+
+	Links map[string][]SongLink `json:"links"` // Ссылки на песню, сгруппированные по провайдеру
+}
+
+// RandomSongsResponse представляет случайно выбранные песни
+type RandomSongsResponse struct {
+	Seed  int64   `json:"seed"`  // Seed, с которым выбор можно повторить
+	Total int     `json:"total"` // Количество песен, подходящих под фильтры
+	Songs []*Song `json:"songs"` // Выбранные песни
 }
@@ -11,4 +11,22 @@ type Song struct {
 	CreatedAt   time.Time `json:"created_at"`
 	ReleaseDate string    `json:"release_date"`
 	Link        string    `json:"link"`
+	DurationMs  *int      `json:"duration_ms,omitempty"` // Длительность в миллисекундах
+	ISRC        string    `json:"isrc"`                  // Международный код записи, например USRC17607839
+	BPM         *int      `json:"bpm,omitempty"`         // Темп, ударов в минуту
+	Key         string    `json:"key"`                   // Тональность, например C#m
+	Explicit    bool      `json:"explicit"`              // Ненормативное содержание
+
+	ExplicitLines []int64 `json:"explicit_lines,omitempty"` // Номера строк (с единицы) с ненормативной лексикой
+
+	Language           string  `json:"language"`            // Язык текста (ISO 639-1)
+	LanguageConfidence float64 `json:"language_confidence"` // Уверенность определения языка от 0 до 1
+	LanguageManual     bool    `json:"language_manual"`     // Язык задан вручную, а не определён автоматически
+}
+
+// LanguageInfo описывает язык текста песни и способ, которым он получен
+type LanguageInfo struct {
+	Language   string  // Код языка ISO 639-1
+	Confidence float64 // Уверенность определения от 0 до 1
+	Manual     bool    // Язык задан вручную
 }
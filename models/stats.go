@@ -0,0 +1,86 @@
+package models
+
+import "time"
+
+// LyricsStats представляет статистику текста песни или нескольких песен
+type LyricsStats struct {
+	Lines           int         `json:"lines"`             // Количество непустых строк
+	Verses          int         `json:"verses"`            // Количество куплетов
+	Words           int         `json:"words"`             // Количество слов
+	UniqueWords     int         `json:"unique_words"`      // Количество различных слов
+	UniqueWordRatio float64     `json:"unique_word_ratio"` // Доля различных слов от 0 до 1
+	TopWords        []WordCount `json:"top_words"`         // Самые частые слова без учёта стоп-слов
+	RepetitionScore float64     `json:"repetition_score"`  // Доля слов в повторяющихся куплетах от 0 до 1
+}
+
+// WordCount представляет слово и число его вхождений
+type WordCount struct {
+	Word  string `json:"word"`  // Слово в нижнем регистре
+	Count int    `json:"count"` // Количество вхождений
+}
+
+// SongStatsResponse представляет статистику текста одной песни
+type SongStatsResponse struct {
+	ID       string `json:"id"`        // UUID песни
+	Group    string `json:"group"`     // Название группы
+	SongName string `json:"song_name"` // Название песни
+	LyricsStats
+}
+
+// GroupStatsResponse представляет статистику текстов всех песен группы
+type GroupStatsResponse struct {
+	Group string `json:"group"` // Название группы
+	Songs int    `json:"songs"` // Количество песен
+	LyricsStats
+}
+
+// SimilarSong представляет песню, похожую по тексту на заданную
+type SimilarSong struct {
+	ID         string  `json:"id"`         // UUID песни
+	Group      string  `json:"group"`      // Название группы
+	SongName   string  `json:"song_name"`  // Название песни
+	Similarity float64 `json:"similarity"` // Косинусная близость текстов от 0 до 1
+	Score      float64 `json:"score"`      // Итоговая оценка с учётом надбавок за группу и жанр
+}
+
+// CatalogueStats представляет сводную статистику музыкальной библиотеки
+type CatalogueStats struct {
+	Songs          int           `json:"songs"`            // Количество песен
+	Groups         int           `json:"groups"`           // Количество групп
+	AvgTextLength  float64       `json:"avg_text_length"`  // Средняя длина текста в символах
+	Missing        MissingCounts `json:"missing"`          // Количество песен без ссылки, текста или даты релиза
+	TopGroups      []GroupCount  `json:"top_groups"`       // Группы с наибольшим числом песен
+	SongsPerYear   []PeriodCount `json:"songs_per_year"`   // Песни по году релиза
+	SongsPerDecade []PeriodCount `json:"songs_per_decade"` // Песни по десятилетию релиза
+	Newest         []RecentSong  `json:"newest"`           // Последние добавленные песни
+	GeneratedAt    time.Time     `json:"generated_at"`     // Время расчёта статистики
+
+	SongsPerGroup []GroupCount `json:"-"` // Полный список групп, отдаётся через /stats/groups
+}
+
+// MissingCounts представляет количество песен с незаполненными полями
+type MissingCounts struct {
+	Link        int `json:"link"`         // Нет ни ссылки, ни ссылок на стриминговые сервисы
+	Text        int `json:"text"`         // Пустой текст
+	ReleaseDate int `json:"release_date"` // Не указана дата релиза
+}
+
+// GroupCount представляет количество песен группы
+type GroupCount struct {
+	Group string `json:"group"` // Название группы
+	Songs int    `json:"songs"` // Количество песен
+}
+
+// PeriodCount представляет количество песен за год или десятилетие
+type PeriodCount struct {
+	Period int `json:"period"` // Год или первый год десятилетия
+	Songs  int `json:"songs"`  // Количество песен
+}
+
+// RecentSong представляет недавно добавленную песню
+type RecentSong struct {
+	ID        string    `json:"id"`         // UUID песни
+	Group     string    `json:"group"`      // Название группы
+	SongName  string    `json:"song_name"`  // Название песни
+	CreatedAt time.Time `json:"created_at"` // Время добавления
+}
@@ -0,0 +1,8 @@
+package models
+
+// Genre представляет жанр из иерархического справочника
+type Genre struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ParentID *int   `json:"parent_id,omitempty"` // Родительский жанр (например, rock для alt-rock)
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Статусы перевода текста песни
+const (
+	TranslationStatusDraft     = "draft"     // Черновик
+	TranslationStatusReview    = "review"    // На проверке
+	TranslationStatusPublished = "published" // Опубликован
+)
+
+// Translation представляет перевод текста песни на другой язык
+type Translation struct {
+	ID         int       `json:"id"`
+	Lang       string    `json:"lang" example:"ru"`          // Код языка (ISO 639-1, например ru или en)
+	Text       string    `json:"text"`                       // Переведённый текст
+	Translator string    `json:"translator" example:"Иван"`  // Автор перевода
+	Status     string    `json:"status" example:"published"` // Статус: draft, review или published
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,225 @@
+// Package profanity находит ненормативную лексику в тексте песни по настраиваемым
+// спискам слов и маскирует её. Встроенные списки покрывают английский и русский языки.
+package profanity
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:embed wordlists/*.txt
+var wordlists embed.FS
+
+// maskRune заменяет буквы найденных слов
+const maskRune = '*'
+
+// Result представляет результат проверки текста
+type Result struct {
+	Explicit bool    // В тексте есть ненормативная лексика
+	Lines    []int64 // Номера строк (с единицы), в которых она найдена
+}
+
+// Filter проверяет слова по точным совпадениям и по началу слова
+type Filter struct {
+	exact    map[string]bool
+	prefixes []string
+}
+
+var (
+	defaultFilter *Filter
+	defaultOnce   sync.Once
+)
+
+// Default возвращает фильтр со встроенными списками слов
+func Default() *Filter {
+	defaultOnce.Do(func() {
+		filter, err := load(wordlists, "wordlists")
+		if err != nil {
+			panic("profanity: embedded word lists are invalid: " + err.Error())
+		}
+		defaultFilter = filter
+	})
+	return defaultFilter
+}
+
+// LoadDir загружает списки слов из файлов *.txt в каталоге dir вместо встроенных
+func LoadDir(dir string) (*Filter, error) {
+	return load(os.DirFS(dir), ".")
+}
+
+// New создаёт фильтр из списка записей; запись со звёздочкой в конце совпадает по началу слова
+func New(entries []string) *Filter {
+	f := &Filter{exact: make(map[string]bool)}
+	for _, entry := range entries {
+		f.add(entry)
+	}
+	return f
+}
+
+func load(fsys fs.FS, dir string) (*Filter, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no word lists found in %s", dir)
+	}
+
+	f := &Filter{exact: make(map[string]bool)}
+	for _, name := range files {
+		file, err := fsys.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		err = f.read(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return f, nil
+}
+
+// read читает записи из списка; пустые строки и комментарии (#) пропускаются
+func (f *Filter) read(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f.add(line)
+	}
+	return scanner.Err()
+}
+
+func (f *Filter) add(entry string) {
+	entry = normalizeWord(strings.TrimSpace(entry))
+	if prefix, ok := strings.CutSuffix(entry, "*"); ok {
+		if prefix != "" {
+			f.prefixes = append(f.prefixes, prefix)
+		}
+		return
+	}
+	if entry != "" {
+		f.exact[entry] = true
+	}
+}
+
+// IsProfane проверяет одно слово
+func (f *Filter) IsProfane(word string) bool {
+	word = normalizeWord(word)
+	if f.exact[word] {
+		return true
+	}
+	for _, prefix := range f.prefixes {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check находит строки текста с ненормативной лексикой
+func (f *Filter) Check(text string) Result {
+	var result Result
+	for i, line := range splitLines(text) {
+		for _, word := range words(line) {
+			if f.IsProfane(word) {
+				result.Explicit = true
+				result.Lines = append(result.Lines, int64(i+1))
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Mask заменяет буквы ненормативных слов звёздочками, сохраняя остальной текст без изменений,
+// включая экранированные переводы строк
+func (f *Filter) Mask(text string) string {
+	runes := []rune(text)
+	var b strings.Builder
+	b.Grow(len(text))
+
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes, i) {
+			b.WriteRune(runes[i])
+			// Экранированный перевод строки \n не должен приклеиваться к следующему слову
+			if runes[i] == '\\' && i+1 < len(runes) && runes[i+1] == 'n' {
+				b.WriteRune('n')
+				i++
+			}
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(runes) && isWordRune(runes, i) {
+			i++
+		}
+		word := string(runes[start:i])
+		if f.IsProfane(word) {
+			b.WriteString(strings.Repeat(string(maskRune), i-start))
+		} else {
+			b.WriteString(word)
+		}
+	}
+	return b.String()
+}
+
+// MaskAll маскирует каждую строку списка, не изменяя исходный срез
+func (f *Filter) MaskAll(texts []string) []string {
+	masked := make([]string, len(texts))
+	for i, text := range texts {
+		masked[i] = f.Mask(text)
+	}
+	return masked
+}
+
+// splitLines разбивает текст на строки; экранированные \n считаются переводами строк
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, `\n`, "\n")
+	return strings.Split(text, "\n")
+}
+
+// words выделяет слова из строки так же, как Mask: апостроф относится к слову только между буквами
+func words(line string) []string {
+	runes := []rune(line)
+	var words []string
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes, i) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && isWordRune(runes, i) {
+			i++
+		}
+		words = append(words, string(runes[start:i]))
+	}
+	return words
+}
+
+// isWordRune проверяет, что символ в позиции i принадлежит слову
+func isWordRune(runes []rune, i int) bool {
+	r := runes[i]
+	if r == '\'' || r == '’' {
+		// Апостроф внутри слова (don't) относится к слову
+		return i > 0 && unicode.IsLetter(runes[i-1]) && i+1 < len(runes) && unicode.IsLetter(runes[i+1])
+	}
+	return unicode.IsLetter(r)
+}
+
+// normalizeWord приводит слово к нижнему регистру и заменяет ё на е
+func normalizeWord(word string) string {
+	return strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+}
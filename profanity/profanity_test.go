@@ -0,0 +1,125 @@
+package profanity
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFilterIsProfane(t *testing.T) {
+	f := New([]string{"darn", "heck*", "ёлки", "*"})
+
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{word: "darn", want: true},
+		{word: "DARN", want: true},
+		{word: "darned"},
+		{word: "heck", want: true},
+		{word: "heckin", want: true},
+		{word: "check"},
+		{word: "елки", want: true},
+		{word: "Ёлки", want: true},
+		{word: ""},
+	}
+	for _, tt := range tests {
+		if got := f.IsProfane(tt.word); got != tt.want {
+			t.Errorf("IsProfane(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestFilterCheck(t *testing.T) {
+	f := New([]string{"darn"})
+
+	tests := []struct {
+		name string
+		text string
+		want Result
+	}{
+		{name: "clean", text: "nothing here\nat all"},
+		{name: "lines", text: "darn it\nfine\nDarn!", want: Result{Explicit: true, Lines: []int64{1, 3}}},
+		{name: "escaped newlines", text: `fine\ndarn`, want: Result{Explicit: true, Lines: []int64{2}}},
+		{name: "once per line", text: "darn darn", want: Result{Explicit: true, Lines: []int64{1}}},
+		{name: "inside another word", text: "darnation"},
+		{name: "single quoted", text: "say 'darn' now", want: Result{Explicit: true, Lines: []int64{1}}},
+		{name: "double quoted", text: `say "darn" now`, want: Result{Explicit: true, Lines: []int64{1}}},
+		{name: "apostrophe inside word", text: "darn't", want: Result{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := f.Check(tt.text)
+			if got.Explicit != tt.want.Explicit || !slices.Equal(got.Lines, tt.want.Lines) {
+				t.Errorf("Check(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterMask(t *testing.T) {
+	f := New([]string{"darn", "don't", "чёрт"})
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "Oh darn, it's fine", want: "Oh ****, it's fine"},
+		{text: `line\ndarn`, want: `line\n****`},
+		{text: "I don't care", want: "I ***** care"},
+		{text: "Чёрт побери", want: "**** побери"},
+		{text: "darnation", want: "darnation"},
+		{text: "say 'darn' now", want: "say '****' now"},
+	}
+	for _, tt := range tests {
+		if got := f.Mask(tt.text); got != tt.want {
+			t.Errorf("Mask(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"lists/en.txt":    {Data: []byte("# comment\n\ndarn\nheck*\n")},
+		"lists/ru.txt":    {Data: []byte("блин\n")},
+		"lists/notes.md":  {Data: []byte("ignored\n")},
+		"empty/readme.md": {Data: []byte("no lists\n")},
+	}
+
+	f, err := load(fsys, "lists")
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	for word, want := range map[string]bool{"darn": true, "heckin": true, "блин": true, "comment": false, "ignored": false} {
+		if got := f.IsProfane(word); got != want {
+			t.Errorf("IsProfane(%q) = %v, want %v", word, got, want)
+		}
+	}
+
+	if _, err := load(fsys, "empty"); err == nil {
+		t.Error("load() of a directory without word lists succeeded")
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "custom.txt"), []byte("zounds\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+	if !f.IsProfane("zounds") {
+		t.Error("word from custom list not detected")
+	}
+}
+
+func TestDefault(t *testing.T) {
+	if f := Default(); f == nil || len(f.exact)+len(f.prefixes) == 0 {
+		t.Error("embedded word lists are empty")
+	}
+}
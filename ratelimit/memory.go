@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"context"
+	"song-libary/config"
+	"sync"
+	"time"
+)
+
+// sweepInterval — как часто хранилища удаляют заполненные корзины
+const sweepInterval = time.Minute
+
+// MemoryStore хранит корзины в памяти процесса; у каждого экземпляра сервиса свои корзины
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]memoryBucket
+	lastSweep time.Time
+}
+
+// memoryBucket — корзина и момент, после которого она заполнена и не нужна
+type memoryBucket struct {
+	bucket
+	fullAt time.Time
+}
+
+// NewMemoryStore создает пустое хранилище в памяти
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]memoryBucket)}
+}
+
+// Take забирает токен из корзины key
+func (s *MemoryStore) Take(_ context.Context, key string, rate config.Rate, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Заполненная корзина ничем не отличается от новой, поэтому такие корзины можно удалять
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		for k, b := range s.buckets {
+			if now.After(b.fullAt) {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	b, result := s.buckets[key].take(rate, now)
+	s.buckets[key] = memoryBucket{bucket: b, fullAt: b.full(rate)}
+	return result, nil
+}
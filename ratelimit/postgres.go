@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"song-libary/config"
+	"song-libary/metrics"
+	"sync"
+	"time"
+)
+
+// PostgresStore хранит корзины в таблице rate_limit_buckets, общей для всех экземпляров сервиса.
+// Строка корзины блокируется на время обновления, поэтому параллельные запросы не получают лишних токенов
+type PostgresStore struct {
+	DB     *sql.DB
+	Logger *slog.Logger
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewPostgresStore создает хранилище корзин в базе данных db
+func NewPostgresStore(db *sql.DB, logger *slog.Logger) *PostgresStore {
+	return &PostgresStore{DB: db, Logger: logger}
+}
+
+// Take забирает токен из корзины key
+func (s *PostgresStore) Take(ctx context.Context, key string, rate config.Rate, now time.Time) (Result, error) {
+	defer metrics.ObserveQuery("rate_limit", "Take", time.Now())
+	s.sweep(ctx, now)
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Новая корзина создаётся заполненной до блокировки, чтобы первые параллельные запросы
+	// ждали друг друга на одной строке
+	query := `
+		INSERT INTO rate_limit_buckets (key, tokens, updated_at, full_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (key) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, key, float64(rate.Requests), now); err != nil {
+		return Result{}, fmt.Errorf("create bucket: %w", err)
+	}
+
+	var b bucket
+	query = `SELECT tokens, updated_at FROM rate_limit_buckets WHERE key = $1 FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, query, key).Scan(&b.tokens, &b.updated); err != nil {
+		return Result{}, fmt.Errorf("lock bucket: %w", err)
+	}
+
+	b, result := b.take(rate, now)
+
+	query = `UPDATE rate_limit_buckets SET tokens = $2, updated_at = $3, full_at = $4 WHERE key = $1`
+	if _, err := tx.ExecContext(ctx, query, key, b.tokens, b.updated, b.full(rate)); err != nil {
+		return Result{}, fmt.Errorf("save bucket: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Result{}, fmt.Errorf("commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// sweep не чаще раза в sweepInterval удаляет заполненные корзины
+func (s *PostgresStore) sweep(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastSweep) < sweepInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSweep = now
+	s.mu.Unlock()
+
+	result, err := s.DB.ExecContext(ctx, `DELETE FROM rate_limit_buckets WHERE full_at < $1`, now)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to delete full rate limit buckets", "error", err)
+		return
+	}
+	if deleted, err := result.RowsAffected(); err == nil && deleted > 0 {
+		s.Logger.DebugContext(ctx, "Deleted full rate limit buckets", "count", deleted)
+	}
+}
@@ -0,0 +1,139 @@
+// Package ratelimit ограничивает частоту запросов клиентов алгоритмом token bucket. Корзина
+// заводится на каждую пару «маршрут — клиент»; состояние корзин хранится в памяти процесса
+// или в PostgreSQL, если экземпляров сервиса несколько.
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"slices"
+	"song-libary/config"
+	"song-libary/metrics"
+	"strings"
+	"time"
+)
+
+// Result описывает решение по запросу
+type Result struct {
+	Allowed    bool
+	Remaining  int           // Запросов, доступных сразу после этого
+	RetryAfter time.Duration // Когда появится следующий токен, если запрос отклонён
+	Reset      time.Duration // Когда корзина заполнится полностью
+}
+
+// Store хранит состояние корзин
+type Store interface {
+	// Take забирает токен из корзины key с ограничением rate на момент now
+	Take(ctx context.Context, key string, rate config.Rate, now time.Time) (Result, error)
+}
+
+// bucket — состояние корзины: число токенов на момент updated
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take пополняет корзину за прошедшее время и забирает из неё токен, если он есть
+func (b bucket) take(rate config.Rate, now time.Time) (bucket, Result) {
+	capacity := float64(rate.Requests)
+	interval := rate.Per / time.Duration(rate.Requests) // Время появления одного токена
+
+	// Часы экземпляров сервиса могут немного расходиться: время корзины не идёт назад
+	if b.updated.IsZero() {
+		b.tokens, b.updated = capacity, now
+	} else if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+float64(elapsed)/float64(interval))
+		b.updated = now
+	}
+
+	var result Result
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(interval))
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = time.Duration((capacity - b.tokens) * float64(interval))
+	return b, result
+}
+
+// full возвращает момент, когда корзина заполнится и её можно забыть
+func (b bucket) full(rate config.Rate) time.Time {
+	interval := rate.Per / time.Duration(rate.Requests)
+	return b.updated.Add(time.Duration((float64(rate.Requests) - b.tokens) * float64(interval)))
+}
+
+// Limiter применяет ограничения из конфигурации к клиентам
+type Limiter struct {
+	Store  Store
+	Config config.RateLimitConfig
+	Logger *slog.Logger
+}
+
+// NewLimiter создает ограничитель с состоянием корзин в store
+func NewLimiter(store Store, cfg config.RateLimitConfig, logger *slog.Logger) *Limiter {
+	return &Limiter{Store: store, Config: cfg, Logger: logger}
+}
+
+// Rate возвращает ограничение для маршрута pattern; нулевое значение — без ограничения
+func (l *Limiter) Rate(pattern string) config.Rate {
+	if !l.Config.Enabled {
+		return config.Rate{}
+	}
+	return l.Config.For(pattern)
+}
+
+// Allow забирает токен клиента запроса r для маршрута pattern. Если хранилище недоступно,
+// запрос пропускается: ограничение частоты не должно останавливать сервис
+func (l *Limiter) Allow(r *http.Request, pattern string) Result {
+	rate := l.Rate(pattern)
+	if rate.Requests == 0 {
+		return Result{Allowed: true}
+	}
+
+	client := l.Client(r)
+	result, err := l.Store.Take(r.Context(), pattern+" "+client, rate, time.Now())
+	if err != nil {
+		l.Logger.ErrorContext(r.Context(), "Rate limiter store failed, allowing request", "error", err)
+		return Result{Allowed: true, Remaining: rate.Requests}
+	}
+	if !result.Allowed {
+		metrics.RateLimited.WithLabelValues(pattern).Inc()
+		l.Logger.WarnContext(r.Context(), "Rate limit exceeded", "route", pattern, "client", client)
+	}
+	return result
+}
+
+// Client определяет клиента запроса: известный ключ API (хранится только его хеш) или IP-адрес.
+// За прокси адрес берётся из X-Forwarded-For: каждый доверенный прокси дописывает в конец адрес,
+// от которого получил запрос, поэтому клиент — TrustedProxies-я запись справа. Записи левее
+// клиент может подставить сам, и они не учитываются
+func (l *Limiter) Client(r *http.Request) string {
+	if key := r.Header.Get(l.Config.APIKeyHeader); key != "" && slices.Contains(l.Config.APIKeys, key) {
+		sum := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(sum[:8])
+	}
+
+	if l.Config.TrustForwardedFor {
+		var hops []string
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, hop := range strings.Split(header, ",") {
+				hops = append(hops, strings.TrimSpace(hop))
+			}
+		}
+		if n := len(hops) - l.Config.TrustedProxies; n >= 0 && n < len(hops) && hops[n] != "" {
+			return "ip:" + hops[n]
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"song-libary/config"
+	"testing"
+	"time"
+)
+
+func TestBucketTake(t *testing.T) {
+	rate := config.Rate{Requests: 2, Per: 2 * time.Second} // Токен в секунду
+	start := time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		bucket bucket
+		now    time.Time
+		want   Result
+	}{
+		{
+			name: "new bucket is full",
+			now:  start,
+			want: Result{Allowed: true, Remaining: 1, Reset: time.Second},
+		},
+		{
+			name:   "empty bucket rejects",
+			bucket: bucket{tokens: 0, updated: start},
+			now:    start,
+			want:   Result{RetryAfter: time.Second, Reset: 2 * time.Second},
+		},
+		{
+			name:   "refills over time",
+			bucket: bucket{tokens: 0, updated: start},
+			now:    start.Add(1500 * time.Millisecond),
+			want:   Result{Allowed: true, Remaining: 0, Reset: 1500 * time.Millisecond},
+		},
+		{
+			name:   "does not refill above capacity",
+			bucket: bucket{tokens: 1, updated: start},
+			now:    start.Add(time.Hour),
+			want:   Result{Allowed: true, Remaining: 1, Reset: time.Second},
+		},
+		{
+			name:   "clock going backwards does not refill",
+			bucket: bucket{tokens: 0.5, updated: start},
+			now:    start.Add(-time.Minute),
+			want:   Result{RetryAfter: 500 * time.Millisecond, Reset: 1500 * time.Millisecond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got := tt.bucket.take(rate, tt.now)
+			if got != tt.want {
+				t.Errorf("take() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreLimitsPerKey(t *testing.T) {
+	store := NewMemoryStore()
+	rate := config.Rate{Requests: 3, Per: time.Minute}
+	now := time.Now()
+
+	for i := range 3 {
+		if result, _ := store.Take(context.Background(), "a", rate, now); !result.Allowed {
+			t.Fatalf("request %d rejected", i+1)
+		}
+	}
+	if result, _ := store.Take(context.Background(), "a", rate, now); result.Allowed {
+		t.Error("request over the limit allowed")
+	}
+	if result, _ := store.Take(context.Background(), "b", rate, now); !result.Allowed {
+		t.Error("other key shares the bucket")
+	}
+}
+
+func TestLimiterClient(t *testing.T) {
+	cfg := config.Default().RateLimit
+	cfg.APIKeys = []string{"secret"}
+
+	tests := []struct {
+		name      string
+		trust     bool
+		proxies   int
+		apiKey    string
+		forwarded []string
+		want      string
+	}{
+		{name: "remote address", want: "ip:10.0.0.1"},
+		{name: "forwarded for ignored without trust", forwarded: []string{"1.1.1.1"}, want: "ip:10.0.0.1"},
+		{name: "single proxy takes rightmost", trust: true, proxies: 1, forwarded: []string{"6.6.6.6, 1.1.1.1"}, want: "ip:1.1.1.1"},
+		{name: "two proxies", trust: true, proxies: 2, forwarded: []string{"6.6.6.6, 1.1.1.1, 10.0.0.7"}, want: "ip:1.1.1.1"},
+		{name: "repeated headers", trust: true, proxies: 1, forwarded: []string{"6.6.6.6", "1.1.1.1"}, want: "ip:1.1.1.1"},
+		{name: "fewer hops than proxies", trust: true, proxies: 2, forwarded: []string{"1.1.1.1"}, want: "ip:10.0.0.1"},
+		{name: "known api key", apiKey: "secret", want: "key:2bb80d537b1da3e3"},
+		{name: "unknown api key", apiKey: "guess", want: "ip:10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := cfg
+			cfg.TrustForwardedFor, cfg.TrustedProxies = tt.trust, tt.proxies
+			limiter := NewLimiter(NewMemoryStore(), cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			r := httptest.NewRequest("GET", "/songs", nil)
+			r.RemoteAddr = "10.0.0.1:54321"
+			if tt.apiKey != "" {
+				r.Header.Set(cfg.APIKeyHeader, tt.apiKey)
+			}
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			if got := limiter.Client(r); got != tt.want {
+				t.Errorf("Client() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Клиент за прокси не получает новую корзину, подставляя в X-Forwarded-For новый адрес
+func TestLimiterIgnoresSpoofedForwardedFor(t *testing.T) {
+	cfg := config.Default().RateLimit
+	cfg.TrustForwardedFor, cfg.TrustedProxies = true, 1
+	cfg.Routes = map[string]config.Rate{"/songs": {Requests: 2, Per: time.Minute}}
+	limiter := NewLimiter(NewMemoryStore(), cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	allowed := 0
+	for i := range 10 {
+		r := httptest.NewRequest("GET", "/songs", nil)
+		r.RemoteAddr = "10.0.0.2:443" // Прокси
+		r.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d, 198.51.100.7", i))
+		if limiter.Allow(r, "/songs").Allowed {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("allowed %d requests with spoofed X-Forwarded-For, want 2", allowed)
+	}
+}
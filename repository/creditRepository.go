@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"song-libary/models"
+)
+
+type CreditRepository interface {
+	GetSongCredits(ctx context.Context, songName, group string) ([]models.Credit, error)
+	SetSongCredits(ctx context.Context, songName, group string, credits []models.Credit) error
+}
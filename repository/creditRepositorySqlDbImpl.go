@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"song-libary/metrics"
+	"song-libary/models"
+	"time"
+)
+
+type CreditRepositorySqlDbImpl struct {
+	DB     *sql.DB
+	Logger *slog.Logger
+}
+
+func NewCreditRepositorySqlDbImpl(db *sql.DB, logger *slog.Logger) *CreditRepositorySqlDbImpl {
+	return &CreditRepositorySqlDbImpl{DB: db, Logger: logger}
+}
+
+// GetSongCredits возвращает участников работы над песней в заданном порядке
+func (r *CreditRepositorySqlDbImpl) GetSongCredits(ctx context.Context, songName, group string) ([]models.Credit, error) {
+	defer metrics.ObserveQuery("credit", "GetSongCredits", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching credits", "song", songName, "group", group)
+
+	var songID string
+	err := r.DB.QueryRowContext(ctx, "SELECT id FROM songs WHERE song_name = $1 AND group_name = $2", songName, group).Scan(&songID)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to fetch song id", "error", err)
+		return nil, err
+	}
+
+	query := `
+		SELECT a.name, sc.role
+		FROM song_credits sc JOIN artists a ON a.id = sc.artist_id
+		WHERE sc.song_id = $1
+		ORDER BY sc.position
+	`
+	rows, err := r.DB.QueryContext(ctx, query, songID)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	credits := []models.Credit{}
+	for rows.Next() {
+		var credit models.Credit
+		if err := rows.Scan(&credit.Name, &credit.Role); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		credits = append(credits, credit)
+	}
+
+	r.Logger.InfoContext(ctx, "Found credits", "count", len(credits), "song", songName)
+	return credits, rows.Err()
+}
+
+// SetSongCredits заменяет список участников работы над песней
+func (r *CreditRepositorySqlDbImpl) SetSongCredits(ctx context.Context, songName, group string, credits []models.Credit) error {
+	defer metrics.ObserveQuery("credit", "SetSongCredits", time.Now())
+
+	r.Logger.InfoContext(ctx, "Setting credits", "count", len(credits), "song", songName, "group", group)
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	songID, err := findSongID(ctx, r.Logger, tx, songName, group)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM song_credits WHERE song_id = $1", songID); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to clear song credits", "error", err)
+		return err
+	}
+
+	for position, credit := range credits {
+		artistID, err := saveArtist(ctx, r.Logger, tx, credit.Name)
+		if err != nil {
+			return err
+		}
+		query := "INSERT INTO song_credits (song_id, artist_id, role, position) VALUES ($1, $2, $3, $4)"
+		if _, err := tx.ExecContext(ctx, query, songID, artistID, credit.Role, position); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to save song credit", "error", err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to commit transaction", "error", err)
+		return err
+	}
+
+	r.Logger.InfoContext(ctx, "Credits updated successfully", "song", songName)
+	return nil
+}
+
+// saveArtist возвращает идентификатор исполнителя name, добавляя его в справочник при необходимости
+func saveArtist(ctx context.Context, logger *slog.Logger, tx *sql.Tx, name string) (int, error) {
+	var artistID int
+	query := "INSERT INTO artists (name) VALUES ($1) ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id"
+	if err := tx.QueryRowContext(ctx, query, name).Scan(&artistID); err != nil {
+		logger.ErrorContext(ctx, "Failed to save artist", "error", err)
+		return 0, err
+	}
+	return artistID, nil
+}
+
+// syncPrimaryCredit делает группу group основным исполнителем песни songID. Если группа переименована
+// из oldGroup, её прежняя запись primary заменяется новой на той же позиции; новая запись без прежней
+// ставится первой. Уже существующая запись group не меняется
+func syncPrimaryCredit(ctx context.Context, logger *slog.Logger, tx *sql.Tx, songID, oldGroup, group string) error {
+	artistID, err := saveArtist(ctx, logger, tx, group)
+	if err != nil {
+		return err
+	}
+
+	var position sql.NullInt64
+	if oldGroup != "" && oldGroup != group {
+		query := `
+			DELETE FROM song_credits
+			WHERE song_id = $1 AND role = 'primary' AND artist_id = (SELECT id FROM artists WHERE name = $2)
+			RETURNING position
+		`
+		err := tx.QueryRowContext(ctx, query, songID, oldGroup).Scan(&position)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			logger.ErrorContext(ctx, "Failed to delete previous primary credit", "error", err)
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO song_credits (song_id, artist_id, role, position)
+		VALUES ($1, $2, 'primary',
+		        COALESCE($3::int, (SELECT MIN(position) - 1 FROM song_credits WHERE song_id = $1), 0))
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.ExecContext(ctx, query, songID, artistID, position); err != nil {
+		logger.ErrorContext(ctx, "Failed to save primary credit", "error", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"log/slog"
+	"slices"
+	"song-libary/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+// statement — выполненный запрос с аргументами
+type statement struct {
+	query string
+	args  []driver.Value
+}
+
+// recordingConn запоминает запросы и отвечает на них строками, которые возвращает reply;
+// rowsErr, если задана, возвращается вместо конца строк
+type recordingConn struct {
+	statements []statement
+	reply      func(query string) (columns []string, rows [][]driver.Value)
+	rowsErr    error
+}
+
+func (c *recordingConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *recordingConn) Driver() driver.Driver                        { return nil }
+func (c *recordingConn) Prepare(string) (driver.Stmt, error)          { panic("unexpected Prepare") }
+func (c *recordingConn) Close() error                                 { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error)                    { return c, nil }
+func (c *recordingConn) Commit() error                                { return nil }
+func (c *recordingConn) Rollback() error                              { return nil }
+
+func (c *recordingConn) record(query string, args []driver.NamedValue) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.statements = append(c.statements, statement{query: strings.Join(strings.Fields(query), " "), args: values})
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.record(query, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.record(query, args)
+	columns, rows := c.reply(query)
+	return &fakeRows{columns: columns, rows: rows, err: c.rowsErr}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		if r.err != nil {
+			return r.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+const (
+	testSongID   = "00000000-0000-0000-0000-000000000001"
+	testArtistID = int64(7)
+)
+
+// songReply отвечает на запросы SaveSong и UpdateSong; oldPosition — позиция прежней записи primary или nil
+func songReply(oldPosition *int64) func(string) ([]string, [][]driver.Value) {
+	return func(query string) ([]string, [][]driver.Value) {
+		switch {
+		case strings.Contains(query, "INSERT INTO songs"):
+			return []string{"id", "created_at"}, [][]driver.Value{{testSongID, time.Now()}}
+		case strings.Contains(query, "UPDATE songs"):
+			return []string{"id", "created_at", "link"}, [][]driver.Value{{testSongID, time.Now(), ""}}
+		case strings.Contains(query, "INSERT INTO artists"):
+			return []string{"id"}, [][]driver.Value{{testArtistID}}
+		case strings.Contains(query, "DELETE FROM song_credits") && oldPosition != nil:
+			return []string{"position"}, [][]driver.Value{{*oldPosition}}
+		}
+		return []string{"position"}, nil
+	}
+}
+
+// creditStatements возвращает запросы к artists и song_credits в порядке выполнения
+func creditStatements(conn *recordingConn) []statement {
+	var credits []statement
+	for _, s := range conn.statements {
+		if strings.Contains(s.query, "artists") || strings.Contains(s.query, "song_credits") {
+			credits = append(credits, s)
+		}
+	}
+	return credits
+}
+
+func TestSongRepositorySyncsPrimaryCredit(t *testing.T) {
+	position := int64(3)
+
+	tests := []struct {
+		name     string
+		run      func(*SongRepositorySqlDbImpl) error
+		position *int64
+		want     []statement // Запросы только с началом текста запроса
+	}{
+		{
+			name: "new song",
+			run: func(r *SongRepositorySqlDbImpl) error {
+				return r.SaveSong(context.Background(), &models.Song{GroupName: "Muse", SongName: "Hysteria"}, nil)
+			},
+			want: []statement{
+				{query: "INSERT INTO artists", args: []driver.Value{"Muse"}},
+				{query: "INSERT INTO song_credits", args: []driver.Value{testSongID, testArtistID, nil}},
+			},
+		},
+		{
+			name: "renamed group keeps position",
+			run: func(r *SongRepositorySqlDbImpl) error {
+				return r.UpdateSong(context.Background(), "Hysteria", "Muse", &models.Song{GroupName: "MUSE", SongName: "Hysteria"}, nil)
+			},
+			position: &position,
+			want: []statement{
+				{query: "INSERT INTO artists", args: []driver.Value{"MUSE"}},
+				{query: "DELETE FROM song_credits", args: []driver.Value{testSongID, "Muse"}},
+				{query: "INSERT INTO song_credits", args: []driver.Value{testSongID, testArtistID, position}},
+			},
+		},
+		{
+			name: "same group",
+			run: func(r *SongRepositorySqlDbImpl) error {
+				return r.UpdateSong(context.Background(), "Hysteria", "Muse", &models.Song{GroupName: "Muse", SongName: "Hysteria (Live)"}, nil)
+			},
+			want: []statement{
+				{query: "INSERT INTO artists", args: []driver.Value{"Muse"}},
+				{query: "INSERT INTO song_credits", args: []driver.Value{testSongID, testArtistID, nil}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &recordingConn{reply: songReply(tt.position)}
+			r := NewSongRepositorySqlDbImpl(sql.OpenDB(conn), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			if err := tt.run(r); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := creditStatements(conn)
+			if len(got) != len(tt.want) {
+				t.Fatalf("credit statements = %+v, want %d", got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(got[i].query, want.query) || !slices.Equal(got[i].args, want.args) {
+					t.Errorf("statement %d = %q %v, want %q %v", i, got[i].query, got[i].args, want.query, want.args)
+				}
+			}
+		})
+	}
+}
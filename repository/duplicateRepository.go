@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"song-libary/models"
+)
+
+type DuplicateRepository interface {
+	SaveDuplicateCandidates(ctx context.Context, candidates []models.DuplicateCandidate) (int, error)
+	GetDuplicateCandidates(ctx context.Context, status string, limit, offset int) ([]models.DuplicateCandidate, error)
+	DismissDuplicateCandidate(ctx context.Context, id int) error
+	MergeSongs(ctx context.Context, survivorID, duplicateID string, fields []string) (*models.Song, error)
+}
@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"log/slog"
+	"slices"
+	"song-libary/metrics"
+	"song-libary/models"
+	"strings"
+	"time"
+)
+
+// mergeValues возвращает столбцы песни, соответствующие полю слияния, и их значения у дубликата
+func mergeValues(field string, song *models.Song) map[string]any {
+	switch field {
+	case models.MergeFieldGroup:
+		return map[string]any{"group_name": song.GroupName}
+	case models.MergeFieldSong:
+		return map[string]any{"song_name": song.SongName}
+	case models.MergeFieldReleaseDate:
+		return map[string]any{"release_date": song.ReleaseDate}
+	case models.MergeFieldLink:
+		return map[string]any{"link": song.Link}
+	case models.MergeFieldDuration:
+		return map[string]any{"duration_ms": song.DurationMs}
+	case models.MergeFieldISRC:
+		return map[string]any{"isrc": sql.NullString{String: song.ISRC, Valid: song.ISRC != ""}}
+	case models.MergeFieldBPM:
+		return map[string]any{"bpm": song.BPM}
+	case models.MergeFieldKey:
+		return map[string]any{"musical_key": song.Key}
+	case models.MergeFieldText:
+		// Признак explicit и язык вычисляются по тексту, поэтому переносятся вместе с ним
+		return map[string]any{
+			"text":                song.Text,
+			"explicit":            song.Explicit,
+			"explicit_lines":      pq.Array(explicitLines(song.ExplicitLines)),
+			"language":            song.Language,
+			"language_confidence": song.LanguageConfidence,
+			"language_manual":     song.LanguageManual,
+		}
+	}
+	return nil
+}
+
+type DuplicateRepositorySqlDbImpl struct {
+	DB     *sql.DB
+	Logger *slog.Logger
+}
+
+func NewDuplicateRepositorySqlDbImpl(db *sql.DB, logger *slog.Logger) *DuplicateRepositorySqlDbImpl {
+	return &DuplicateRepositorySqlDbImpl{DB: db, Logger: logger}
+}
+
+// SaveDuplicateCandidates сохраняет найденные пары. Уже отклонённые пары не возвращаются на проверку,
+// у ожидающих обновляется близость текстов. Возвращает число новых кандидатов
+func (r *DuplicateRepositorySqlDbImpl) SaveDuplicateCandidates(ctx context.Context, candidates []models.DuplicateCandidate) (int, error) {
+	defer metrics.ObserveQuery("duplicate", "SaveDuplicateCandidates", time.Now())
+
+	r.Logger.InfoContext(ctx, "Saving duplicate candidates", "count", len(candidates))
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to begin transaction", "error", err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO duplicate_candidates (song_id, duplicate_id, similarity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (song_id, duplicate_id) DO UPDATE
+		SET similarity = EXCLUDED.similarity
+		WHERE duplicate_candidates.status = 'pending'
+		RETURNING (xmax = 0)
+	`
+	created := 0
+	for _, candidate := range candidates {
+		var inserted bool
+		err := tx.QueryRowContext(ctx, query, candidate.SongID, candidate.DuplicateID, candidate.Similarity).Scan(&inserted)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// Пара уже отклонена
+		case err != nil:
+			r.Logger.ErrorContext(ctx, "Failed to save duplicate candidate", "error", err)
+			return 0, err
+		case inserted:
+			created++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to commit transaction", "error", err)
+		return 0, err
+	}
+
+	r.Logger.InfoContext(ctx, "Saved new duplicate candidates", "created", created)
+	return created, nil
+}
+
+// GetDuplicateCandidates возвращает кандидатов в дубликаты с заданным статусом, начиная с самых похожих
+func (r *DuplicateRepositorySqlDbImpl) GetDuplicateCandidates(ctx context.Context, status string, limit, offset int) ([]models.DuplicateCandidate, error) {
+	defer metrics.ObserveQuery("duplicate", "GetDuplicateCandidates", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching duplicate candidates", "status", status)
+
+	query := `
+		SELECT dc.id, dc.song_id, s.group_name, s.song_name, dc.duplicate_id, d.group_name, d.song_name,
+		       dc.similarity, dc.status, dc.created_at
+		FROM duplicate_candidates dc
+		JOIN songs s ON s.id = dc.song_id
+		JOIN songs d ON d.id = dc.duplicate_id
+		WHERE dc.status = $1
+		ORDER BY dc.similarity DESC, dc.id
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.DB.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []models.DuplicateCandidate{}
+	for rows.Next() {
+		var c models.DuplicateCandidate
+		err := rows.Scan(&c.ID, &c.SongID, &c.Group, &c.SongName, &c.DuplicateID, &c.DuplicateGroup, &c.DuplicateSongName,
+			&c.Similarity, &c.Status, &c.CreatedAt)
+		if err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// DismissDuplicateCandidate помечает пару как разные песни
+func (r *DuplicateRepositorySqlDbImpl) DismissDuplicateCandidate(ctx context.Context, id int) error {
+	defer metrics.ObserveQuery("duplicate", "DismissDuplicateCandidate", time.Now())
+
+	r.Logger.InfoContext(ctx, "Dismissing duplicate candidate", "id", id)
+
+	result, err := r.DB.ExecContext(ctx, "UPDATE duplicate_candidates SET status = 'dismissed' WHERE id = $1", id)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to dismiss duplicate candidate", "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// MergeSongs объединяет дубликат с оставляемой песней в одной транзакции: жанры, теги, участники,
+// ссылки и переводы дубликата переносятся на оставляемую песню, дубликат удаляется,
+// а перечисленные в fields поля берутся у дубликата
+func (r *DuplicateRepositorySqlDbImpl) MergeSongs(ctx context.Context, survivorID, duplicateID string, fields []string) (*models.Song, error) {
+	defer metrics.ObserveQuery("duplicate", "MergeSongs", time.Now())
+
+	r.Logger.InfoContext(ctx, "Merging songs", "duplicate_id", duplicateID, "survivor_id", survivorID, "fields", fields)
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to begin transaction", "error", err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Блокируем обе песни, чтобы их не изменили во время слияния
+	var locked int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM (SELECT id FROM songs WHERE id IN ($1, $2) FOR UPDATE) s`, survivorID, duplicateID).Scan(&locked)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to lock songs", "error", err)
+		return nil, err
+	}
+	if locked != 2 {
+		return nil, sql.ErrNoRows
+	}
+
+	duplicate, err := scanSong(tx.QueryRowContext(ctx, fmt.Sprintf("SELECT %s FROM songs WHERE id = $1", songColumns), duplicateID))
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to fetch duplicate song", "error", err)
+		return nil, err
+	}
+	var previousGroup, previousLink string
+	err = tx.QueryRowContext(ctx, "SELECT group_name, link FROM songs WHERE id = $1", survivorID).Scan(&previousGroup, &previousLink)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to fetch surviving song", "error", err)
+		return nil, err
+	}
+
+	moves := []string{
+		`INSERT INTO song_genres (song_id, genre_id)
+		 SELECT $1, genre_id FROM song_genres WHERE song_id = $2
+		 ON CONFLICT DO NOTHING`,
+		`INSERT INTO song_tags (song_id, tag_id)
+		 SELECT $1, tag_id FROM song_tags WHERE song_id = $2
+		 ON CONFLICT DO NOTHING`,
+		`INSERT INTO song_credits (song_id, artist_id, role, position)
+		 SELECT $1, artist_id, role,
+		        position + (SELECT COALESCE(MAX(position) + 1, 0) FROM song_credits WHERE song_id = $1)
+		 FROM song_credits WHERE song_id = $2
+		 ON CONFLICT DO NOTHING`,
+		`UPDATE song_links SET song_id = $1
+		 WHERE song_id = $2 AND url NOT IN (SELECT url FROM song_links WHERE song_id = $1)`,
+		`UPDATE song_translations SET song_id = $1
+		 WHERE song_id = $2 AND lang NOT IN (SELECT lang FROM song_translations WHERE song_id = $1)`,
+	}
+	for _, move := range moves {
+		if _, err := tx.ExecContext(ctx, move, survivorID, duplicateID); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to move related data", "error", err)
+			return nil, err
+		}
+	}
+
+	// Дубликат удаляется до обновления, чтобы его ISRC можно было перенести без нарушения уникальности
+	if _, err := tx.ExecContext(ctx, "DELETE FROM songs WHERE id = $1", duplicateID); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to delete duplicate song", "error", err)
+		return nil, err
+	}
+
+	assignments := []string{}
+	args := []any{survivorID}
+	for _, field := range fields {
+		for column, value := range mergeValues(field, duplicate) {
+			args = append(args, value)
+			assignments = append(assignments, fmt.Sprintf("%s = $%d", column, len(args)))
+		}
+	}
+
+	var survivor *models.Song
+	if len(assignments) > 0 {
+		query := fmt.Sprintf("UPDATE songs SET %s WHERE id = $1 RETURNING %s", strings.Join(assignments, ", "), songColumns)
+		survivor, err = scanSong(tx.QueryRowContext(ctx, query, args...))
+	} else {
+		survivor, err = scanSong(tx.QueryRowContext(ctx, fmt.Sprintf("SELECT %s FROM songs WHERE id = $1", songColumns), survivorID))
+	}
+	if err != nil {
+		if isUniqueViolation(err, "idx_songs_isrc") {
+			return nil, ErrDuplicateISRC
+		}
+		r.Logger.ErrorContext(ctx, "Failed to update surviving song", "error", err)
+		return nil, err
+	}
+
+	if slices.Contains(fields, models.MergeFieldLink) {
+		if err := r.syncMergedLink(ctx, tx, survivorID, previousLink, survivor.Link); err != nil {
+			return nil, err
+		}
+	}
+	if slices.Contains(fields, models.MergeFieldGroup) {
+		if err := syncPrimaryCredit(ctx, r.Logger, tx, survivorID, previousGroup, survivor.GroupName); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to commit transaction", "error", err)
+		return nil, err
+	}
+
+	r.Logger.InfoContext(ctx, "Song merged", "duplicate_id", duplicateID, "survivor_id", survivorID)
+	return survivor, nil
+}
+
+// syncMergedLink приводит song_links в соответствие со ссылкой, взятой у дубликата. Строка новой ссылки
+// к этому моменту уже перенесена на оставляемую песню вместе с остальными ссылками дубликата,
+// поэтому её данные о провайдере сохраняются; прежняя ссылка оставляемой песни удаляется, как в UpdateSong
+func (r *DuplicateRepositorySqlDbImpl) syncMergedLink(ctx context.Context, tx *sql.Tx, survivorID, previousURL, url string) error {
+	var link *models.SongLink
+	if url != "" {
+		link = &models.SongLink{URL: url}
+		err := tx.QueryRowContext(ctx, "SELECT provider, external_id, region FROM song_links WHERE song_id = $1 AND url = $2",
+			survivorID, url).Scan(&link.Provider, &link.ExternalID, &link.Region)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// У дубликата не было строки в song_links; провайдер не определён, как при переносе старых ссылок
+			link.Provider = models.LinkProviderOther
+		case err != nil:
+			r.Logger.ErrorContext(ctx, "Failed to fetch merged song link", "error", err)
+			return err
+		}
+	}
+	return syncSongLink(ctx, r.Logger, tx, survivorID, previousURL, link)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"errors"
+	"github.com/lib/pq"
+)
+
+// ErrDuplicateISRC возвращается при попытке сохранить уже занятый ISRC
+var ErrDuplicateISRC = errors.New("isrc already exists")
+
+// uniqueViolationCode — код ошибки PostgreSQL при нарушении уникальности
+const uniqueViolationCode = "23505"
+
+// isUniqueViolation проверяет, что ошибка вызвана нарушением указанного ограничения уникальности
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode && pqErr.Constraint == constraint
+}
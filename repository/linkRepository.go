@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"song-libary/models"
+	"time"
+)
+
+// ErrDuplicateLink возвращается, если у песни уже есть такая ссылка
+var ErrDuplicateLink = errors.New("link already exists")
+
+type LinkRepository interface {
+	AddSongLink(ctx context.Context, songName, group string, link *models.SongLink) error
+	DeleteSongLink(ctx context.Context, id int) error
+	GetSongLinks(ctx context.Context, songName, group string) ([]*models.SongLink, error)
+	GetLinksToCheck(ctx context.Context, recheckAfter time.Duration, limit int) ([]*models.SongLink, error)
+	SaveLinkCheck(ctx context.Context, id, statusCode int, ok bool, brokenAfter int) error
+	DeferLinkCheck(ctx context.Context, id int, until time.Time) error
+	GetBrokenLinks(ctx context.Context) ([]*models.BrokenLink, error)
+}
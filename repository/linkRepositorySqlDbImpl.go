@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"song-libary/metrics"
+	"song-libary/models"
+	"time"
+)
+
+type LinkRepositorySqlDbImpl struct {
+	DB     *sql.DB
+	Logger *slog.Logger
+}
+
+func NewLinkRepositorySqlDbImpl(db *sql.DB, logger *slog.Logger) *LinkRepositorySqlDbImpl {
+	return &LinkRepositorySqlDbImpl{DB: db, Logger: logger}
+}
+
+// AddSongLink сохраняет ссылку на песню
+func (r *LinkRepositorySqlDbImpl) AddSongLink(ctx context.Context, songName, group string, link *models.SongLink) error {
+	defer metrics.ObserveQuery("link", "AddSongLink", time.Now())
+
+	r.Logger.InfoContext(ctx, "Adding link", "provider", link.Provider, "song", songName, "group", group)
+
+	query := `
+		INSERT INTO song_links (song_id, provider, url, external_id, region)
+		SELECT id, $3, $4, $5, $6 FROM songs WHERE song_name = $1 AND group_name = $2
+		RETURNING id
+	`
+	err := r.DB.QueryRowContext(ctx, query, songName, group, link.Provider, link.URL, link.ExternalID, link.Region).Scan(&link.ID)
+	if err != nil {
+		if isUniqueViolation(err, "song_links_song_id_url_key") {
+			r.Logger.InfoContext(ctx, "Link already exists", "url", link.URL)
+			return ErrDuplicateLink
+		}
+		r.Logger.ErrorContext(ctx, "Failed to add song link", "error", err)
+		return err
+	}
+
+	r.Logger.InfoContext(ctx, "Song link added", "id", link.ID)
+	return nil
+}
+
+// DeleteSongLink удаляет ссылку по идентификатору
+func (r *LinkRepositorySqlDbImpl) DeleteSongLink(ctx context.Context, id int) error {
+	defer metrics.ObserveQuery("link", "DeleteSongLink", time.Now())
+
+	r.Logger.InfoContext(ctx, "Deleting song link", "id", id)
+
+	result, err := r.DB.ExecContext(ctx, "DELETE FROM song_links WHERE id = $1", id)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to delete song link", "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		r.Logger.InfoContext(ctx, "No song link found", "id", id)
+		return sql.ErrNoRows
+	}
+
+	r.Logger.InfoContext(ctx, "Song link deleted successfully", "id", id)
+	return nil
+}
+
+// GetSongLinks возвращает все ссылки на песню
+func (r *LinkRepositorySqlDbImpl) GetSongLinks(ctx context.Context, songName, group string) ([]*models.SongLink, error) {
+	defer metrics.ObserveQuery("link", "GetSongLinks", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching links", "song", songName, "group", group)
+
+	var songID string
+	err := r.DB.QueryRowContext(ctx, "SELECT id FROM songs WHERE song_name = $1 AND group_name = $2", songName, group).Scan(&songID)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to fetch song id", "error", err)
+		return nil, err
+	}
+
+	query := `
+		SELECT id, provider, url, external_id, region, status, status_code, last_checked_at, failure_streak
+		FROM song_links
+		WHERE song_id = $1
+		ORDER BY provider, id
+	`
+	rows, err := r.DB.QueryContext(ctx, query, songID)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []*models.SongLink{}
+	for rows.Next() {
+		link := &models.SongLink{}
+		if err := rows.Scan(&link.ID, &link.Provider, &link.URL, &link.ExternalID, &link.Region,
+			&link.Status, &link.StatusCode, &link.LastCheckedAt, &link.FailureStreak); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	r.Logger.InfoContext(ctx, "Found links", "count", len(links), "song", songName)
+	return links, rows.Err()
+}
+
+// GetLinksToCheck возвращает http(s)-ссылки, которые не проверялись дольше recheckAfter.
+// Ссылки, проверка которых отложена DeferLinkCheck, не возвращаются до наступления next_check_at
+func (r *LinkRepositorySqlDbImpl) GetLinksToCheck(ctx context.Context, recheckAfter time.Duration, limit int) ([]*models.SongLink, error) {
+	defer metrics.ObserveQuery("link", "GetLinksToCheck", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching links to check", "limit", limit, "recheck_after", recheckAfter)
+
+	query := `
+		SELECT id, provider, url, external_id, region, status, status_code, last_checked_at, failure_streak
+		FROM song_links
+		WHERE url ~* '^https?://'
+		  AND (last_checked_at IS NULL OR last_checked_at < timezone('UTC', now()) - $1 * INTERVAL '1 second')
+		  AND (next_check_at IS NULL OR next_check_at <= timezone('UTC', now()))
+		ORDER BY last_checked_at NULLS FIRST
+		LIMIT $2
+	`
+	rows, err := r.DB.QueryContext(ctx, query, recheckAfter.Seconds(), limit)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*models.SongLink
+	for rows.Next() {
+		link := &models.SongLink{}
+		if err := rows.Scan(&link.ID, &link.Provider, &link.URL, &link.ExternalID, &link.Region,
+			&link.Status, &link.StatusCode, &link.LastCheckedAt, &link.FailureStreak); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	r.Logger.InfoContext(ctx, "Found links to check", "count", len(links))
+	return links, rows.Err()
+}
+
+// SaveLinkCheck сохраняет результат проверки ссылки. Ссылка помечается нерабочей,
+// когда число неудачных проверок подряд достигает brokenAfter; statusCode 0 означает сетевую ошибку
+func (r *LinkRepositorySqlDbImpl) SaveLinkCheck(ctx context.Context, id, statusCode int, ok bool, brokenAfter int) error {
+	defer metrics.ObserveQuery("link", "SaveLinkCheck", time.Now())
+
+	r.Logger.DebugContext(ctx, "Saving link check", "id", id, "status_code", statusCode, "ok", ok)
+
+	query := `
+		UPDATE song_links
+		SET status_code = NULLIF($2, 0),
+		    last_checked_at = timezone('UTC', now()),
+		    next_check_at = NULL,
+		    failure_streak = CASE WHEN $3 THEN 0 ELSE failure_streak + 1 END,
+		    status = CASE
+		                 WHEN $3 THEN 'ok'
+		                 WHEN failure_streak + 1 >= $4 THEN 'broken'
+		                 ELSE status
+		             END
+		WHERE id = $1
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id, statusCode, ok, brokenAfter); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to save link check", "error", err)
+		return err
+	}
+	return nil
+}
+
+// DeferLinkCheck откладывает проверку ссылки до until, не меняя результат прошлой проверки
+func (r *LinkRepositorySqlDbImpl) DeferLinkCheck(ctx context.Context, id int, until time.Time) error {
+	defer metrics.ObserveQuery("link", "DeferLinkCheck", time.Now())
+
+	r.Logger.DebugContext(ctx, "Deferring link check", "id", id, "until", until)
+
+	if _, err := r.DB.ExecContext(ctx, "UPDATE song_links SET next_check_at = $2 WHERE id = $1", id, until.UTC()); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to defer link check", "error", err)
+		return err
+	}
+	return nil
+}
+
+// GetBrokenLinks возвращает нерабочие ссылки вместе с песнями, к которым они относятся
+func (r *LinkRepositorySqlDbImpl) GetBrokenLinks(ctx context.Context) ([]*models.BrokenLink, error) {
+	defer metrics.ObserveQuery("link", "GetBrokenLinks", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching broken links report")
+
+	query := `
+		SELECT s.group_name, s.song_name,
+		       sl.id, sl.provider, sl.url, sl.external_id, sl.region, sl.status, sl.status_code, sl.last_checked_at, sl.failure_streak
+		FROM song_links sl JOIN songs s ON s.id = sl.song_id
+		WHERE sl.status = 'broken'
+		ORDER BY sl.failure_streak DESC, s.group_name, s.song_name
+	`
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := []*models.BrokenLink{}
+	for rows.Next() {
+		link := &models.BrokenLink{}
+		if err := rows.Scan(&link.GroupName, &link.SongName,
+			&link.ID, &link.Provider, &link.URL, &link.ExternalID, &link.Region,
+			&link.Status, &link.StatusCode, &link.LastCheckedAt, &link.FailureStreak); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		report = append(report, link)
+	}
+
+	r.Logger.InfoContext(ctx, "Found broken links", "count", len(report))
+	return report, rows.Err()
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/lib/pq"
+	"song-libary/models"
+	"strings"
+)
+
+// songFilter собирает условия WHERE и аргументы запроса по параметрам фильтрации
+type songFilter struct {
+	conditions []string
+	args       []any
+}
+
+// arg добавляет аргумент запроса и возвращает его плейсхолдер
+func (f *songFilter) arg(value any) string {
+	f.args = append(f.args, value)
+	return fmt.Sprintf("$%d", len(f.args))
+}
+
+// add добавляет условие фильтрации
+func (f *songFilter) add(condition string) {
+	f.conditions = append(f.conditions, condition)
+}
+
+// where возвращает секцию WHERE (или пустую строку, если условий нет)
+func (f *songFilter) where() string {
+	if len(f.conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(f.conditions, "\n\t\t  AND ")
+}
+
+// buildSongFilter формирует условия фильтрации песен по FilterParams
+func buildSongFilter(params models.FilterParams) *songFilter {
+	f := &songFilter{}
+
+	if params.Group != "" {
+		f.add("group_name ILIKE '%' || " + f.arg(params.Group) + " || '%'")
+	}
+	if params.SongName != "" {
+		f.add("song_name ILIKE '%' || " + f.arg(params.SongName) + " || '%'")
+	}
+	if params.Text != "" {
+		f.add("text ILIKE '%' || " + f.arg(params.Text) + " || '%'")
+	}
+	if params.ReleaseDate != "" {
+		f.add("release_date <= " + f.arg(params.ReleaseDate))
+	}
+
+	if params.BPMMin != nil {
+		f.add("bpm >= " + f.arg(*params.BPMMin))
+	}
+	if params.BPMMax != nil {
+		f.add("bpm <= " + f.arg(*params.BPMMax))
+	}
+	if params.DurationMin != nil {
+		f.add("duration_ms >= " + f.arg(*params.DurationMin))
+	}
+	if params.DurationMax != nil {
+		f.add("duration_ms <= " + f.arg(*params.DurationMax))
+	}
+	if params.Key != "" {
+		f.add("musical_key = " + f.arg(params.Key))
+	}
+
+	if params.Language != "" {
+		f.add("language = " + f.arg(params.Language))
+	}
+	if params.NoExplicit {
+		f.add("NOT explicit")
+	}
+	if params.LinkStatus != "" {
+		f.add("id IN (SELECT song_id FROM song_links WHERE status = " + f.arg(params.LinkStatus) + ")")
+	}
+
+	// Жанр учитывает все поджанры через рекурсивный обход дерева
+	if params.Genre != "" {
+		f.add(`id IN (
+			SELECT sg.song_id FROM song_genres sg
+			WHERE sg.genre_id IN (
+				WITH RECURSIVE subgenres AS (
+					SELECT id FROM genres WHERE name = lower(` + f.arg(params.Genre) + `)
+					UNION ALL
+					SELECT g.id FROM genres g JOIN subgenres s ON g.parent_id = s.id
+				)
+				SELECT id FROM subgenres
+			)
+		)`)
+	}
+
+	if len(params.Tags) > 0 {
+		subquery := `SELECT st.song_id FROM song_tags st JOIN tags t ON t.id = st.tag_id
+			WHERE t.name = ANY(` + f.arg(pq.Array(params.Tags)) + `)`
+		if params.TagsMatch == models.TagsMatchAll {
+			subquery += " GROUP BY st.song_id HAVING COUNT(DISTINCT t.name) = " + f.arg(len(params.Tags))
+		}
+		f.add("id IN (" + subquery + ")")
+	}
+
+	// Участник ищется и среди исполнителей, и в списке авторов песни
+	if params.Credited != "" {
+		credited := f.arg(params.Credited)
+		f.add(`(lower(group_name) = lower(` + credited + `) OR id IN (
+			SELECT sc.song_id FROM song_credits sc JOIN artists a ON a.id = sc.artist_id
+			WHERE lower(a.name) = lower(` + credited + `)
+		))`)
+	}
+
+	return f
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"github.com/lib/pq"
+	"reflect"
+	"song-libary/models"
+	"strings"
+	"testing"
+)
+
+func TestBuildSongFilterTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   models.FilterParams
+		wantSQL  []string // Фрагменты условия WHERE
+		wantArgs []any
+	}{
+		{
+			name:     "any tag",
+			params:   models.FilterParams{Tags: []string{"live", "ballad"}, TagsMatch: models.TagsMatchAny},
+			wantSQL:  []string{"id IN (SELECT st.song_id FROM song_tags st", "t.name = ANY($1)"},
+			wantArgs: []any{pq.Array([]string{"live", "ballad"})},
+		},
+		{
+			name:     "all tags",
+			params:   models.FilterParams{Group: "Muse", Tags: []string{"live", "ballad"}, TagsMatch: models.TagsMatchAll},
+			wantSQL:  []string{"group_name ILIKE '%' || $1 || '%'", "t.name = ANY($2)", "GROUP BY st.song_id HAVING COUNT(DISTINCT t.name) = $3"},
+			wantArgs: []any{"Muse", pq.Array([]string{"live", "ballad"}), 2},
+		},
+		{name: "no tags", params: models.FilterParams{TagsMatch: models.TagsMatchAll}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := buildSongFilter(tt.params)
+			where := f.where()
+			for _, fragment := range tt.wantSQL {
+				if !strings.Contains(where, fragment) {
+					t.Errorf("where = %q, want fragment %q", where, fragment)
+				}
+			}
+			if len(tt.wantSQL) == 0 && where != "" {
+				t.Errorf("where = %q, want no conditions", where)
+			}
+			if !reflect.DeepEqual(f.args, tt.wantArgs) {
+				t.Errorf("args = %#v, want %#v", f.args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -1,12 +1,23 @@
 package repository
 
-import "song-libary/models"
+import (
+	"context"
+	"song-libary/models"
+)
 
 type SongRepository interface {
-	SaveSong(song *models.Song) error
-	DeleteBySongNameAndGroup(songName, group string) error
-	UpdateSong(req models.UpdateSongRequest) error
-	FindSongs(params models.FilterParams) ([]*models.Song, error)
-	GetSongTextByNameAndGroup(songName, group string) (string, error)
-	GetSongInfo(group, songName string) (*models.SongDetail, error)
+	SaveSong(ctx context.Context, song *models.Song, link *models.SongLink) error
+	DeleteBySongNameAndGroup(ctx context.Context, songName, group string) (string, error)
+	UpdateSong(ctx context.Context, oldSongName, oldGroup string, song *models.Song, link *models.SongLink) error
+	FindSongs(ctx context.Context, params models.FilterParams) ([]*models.Song, error)
+	SampleSongs(ctx context.Context, params models.FilterParams, pick func(total int) []int64) (int, []*models.Song, error)
+	FindSongsReleasedOn(ctx context.Context, month, day, limit, offset int) ([]*models.Song, error)
+	GetSongTextByNameAndGroup(ctx context.Context, songName, group string) (string, error)
+	GetSongInfo(ctx context.Context, group, songName string) (*models.SongDetail, error)
+	GetSongByID(ctx context.Context, id string) (*models.Song, error)
+	GetSongsByGroup(ctx context.Context, group string) ([]*models.Song, error)
+	ListSongs(ctx context.Context, afterID string, limit int) ([]*models.Song, error)
+	GetSongsSharingGenre(ctx context.Context, id string) ([]string, error)
+	GetSongsWithoutLanguage(ctx context.Context, afterID string, limit int) ([]*models.Song, error)
+	UpdateSongLanguage(ctx context.Context, id string, language models.LanguageInfo) error
 }
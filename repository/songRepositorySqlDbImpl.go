@@ -1,158 +1,582 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
-	"log"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"log/slog"
+	"song-libary/metrics"
 	"song-libary/models"
+	"time"
 )
 
-type SongRepositorySqlDbImpl struct {
-	DB *sql.DB
-}
+// songColumns перечисляет столбцы песни в порядке, ожидаемом scanSong
+const songColumns = `id, group_name, song_name, text, created_at, release_date, link,
+		       duration_ms, COALESCE(isrc, ''), bpm, musical_key, explicit, explicit_lines,
+		       language, language_confidence, language_manual`
 
-func NewSongRepositorySqlDbImpl(db *sql.DB) *SongRepositorySqlDbImpl {
-	return &SongRepositorySqlDbImpl{DB: db}
+// rowScanner обобщает *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
 }
 
-func (r *SongRepositorySqlDbImpl) SaveSong(song *models.Song) error {
-	log.Printf("[INFO] Saving song to database: %+v", song)
-
-	query := "INSERT INTO songs (group_name, song_name, text, release_date, link) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at"
-	err := r.DB.QueryRow(query, song.GroupName, song.SongName, song.Text, song.ReleaseDate, song.Link).Scan(&song.ID, &song.CreatedAt)
+// scanSong читает песню из строки результата, выбранной по songColumns
+func scanSong(row rowScanner) (*models.Song, error) {
+	song := &models.Song{}
+	err := row.Scan(&song.ID, &song.GroupName, &song.SongName, &song.Text, &song.CreatedAt, &song.ReleaseDate, &song.Link,
+		&song.DurationMs, &song.ISRC, &song.BPM, &song.Key, &song.Explicit, pq.Array(&song.ExplicitLines),
+		&song.Language, &song.LanguageConfidence, &song.LanguageManual)
 	if err != nil {
-		log.Printf("[ERROR] Failed to save song: %v", err)
-		return err
+		return nil, err
 	}
+	return song, nil
+}
 
-	log.Printf("[DEBUG] Song saved with ID: %s", song.ID)
-	return nil
+type SongRepositorySqlDbImpl struct {
+	DB     *sql.DB
+	Logger *slog.Logger
+}
+
+func NewSongRepositorySqlDbImpl(db *sql.DB, logger *slog.Logger) *SongRepositorySqlDbImpl {
+	return &SongRepositorySqlDbImpl{DB: db, Logger: logger}
 }
 
-// DeleteBySongNameAndGroup удаляет песню по её названию
-func (r *SongRepositorySqlDbImpl) DeleteBySongNameAndGroup(songName, group string) error {
-	log.Printf("[INFO] Deleting song with name: %s", songName)
+// SaveSong сохраняет новую песню и заполняет её идентификатор. Ссылка link, если задана,
+// сохраняется в song_links, а группа записывается основным исполнителем в song_credits в той же транзакции
+func (r *SongRepositorySqlDbImpl) SaveSong(ctx context.Context, song *models.Song, link *models.SongLink) error {
+	defer metrics.ObserveQuery("song", "SaveSong", time.Now())
+
+	r.Logger.InfoContext(ctx, "Saving song to database", "song", song.SongName, "group", song.GroupName)
 
-	query := "DELETE FROM songs WHERE song_name = $1 AND group_name = $2"
-	result, err := r.DB.Exec(query, songName, group)
+	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
-		log.Printf("[ERROR] Failed to delete song: %v", err)
+		r.Logger.ErrorContext(ctx, "Failed to begin transaction", "error", err)
 		return err
 	}
+	defer tx.Rollback()
 
-	// Проверяем количество удалённых записей
-	rowsAffected, err := result.RowsAffected()
+	query := `
+		INSERT INTO songs (group_name, song_name, text, release_date, link, duration_ms, isrc, bpm, musical_key, explicit,
+		                   explicit_lines, language, language_confidence, language_manual)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRowContext(ctx, query, song.GroupName, song.SongName, song.Text, song.ReleaseDate, song.Link,
+		song.DurationMs, song.ISRC, song.BPM, song.Key, song.Explicit, pq.Array(explicitLines(song.ExplicitLines)),
+		song.Language, song.LanguageConfidence, song.LanguageManual).Scan(&song.ID, &song.CreatedAt)
 	if err != nil {
-		log.Printf("[ERROR] Failed to get rows affected: %v", err)
+		if isUniqueViolation(err, "idx_songs_isrc") {
+			r.Logger.InfoContext(ctx, "ISRC already exists", "isrc", song.ISRC)
+			return ErrDuplicateISRC
+		}
+		r.Logger.ErrorContext(ctx, "Failed to save song", "error", err)
 		return err
 	}
 
-	if rowsAffected == 0 {
-		log.Printf("[INFO] No song found with name: %s", songName)
-		return sql.ErrNoRows
+	if err := syncSongLink(ctx, r.Logger, tx, song.ID, "", link); err != nil {
+		return err
+	}
+	if err := syncPrimaryCredit(ctx, r.Logger, tx, song.ID, "", song.GroupName); err != nil {
+		return err
 	}
 
-	log.Printf("[INFO] Song deleted successfully: %s", songName)
+	if err := tx.Commit(); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to commit transaction", "error", err)
+		return err
+	}
+
+	r.Logger.DebugContext(ctx, "Song saved", "id", song.ID)
 	return nil
 }
 
-// UpdateSong изменяет данные песни
-func (r *SongRepositorySqlDbImpl) UpdateSong(req models.UpdateSongRequest) error {
-	log.Printf("[INFO] Updating song: oldName=%s, oldGroup=%s, newGroup=%s, newName=%s", req.OldSongName, req.OldGroup, req.NewGroup, req.NewSongName)
+// DeleteBySongNameAndGroup удаляет песню по её названию и возвращает идентификатор удалённой песни
+func (r *SongRepositorySqlDbImpl) DeleteBySongNameAndGroup(ctx context.Context, songName, group string) (string, error) {
+	defer metrics.ObserveQuery("song", "DeleteBySongNameAndGroup", time.Now())
+
+	r.Logger.InfoContext(ctx, "Deleting song", "song", songName)
+
+	query := "DELETE FROM songs WHERE song_name = $1 AND group_name = $2 RETURNING id"
+	var id string
+	if err := r.DB.QueryRowContext(ctx, query, songName, group).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			r.Logger.InfoContext(ctx, "No song found", "song", songName)
+		} else {
+			r.Logger.ErrorContext(ctx, "Failed to delete song", "error", err)
+		}
+		return "", err
+	}
+
+	r.Logger.InfoContext(ctx, "Song deleted successfully", "song", songName)
+	return id, nil
+}
+
+// UpdateSong заменяет данные песни, найденной по старому названию и группе, и заполняет её идентификатор.
+// Прежняя ссылка песни удаляется из song_links, новая ссылка link сохраняется, а основной исполнитель
+// в song_credits следует за названием группы в той же транзакции
+func (r *SongRepositorySqlDbImpl) UpdateSong(ctx context.Context, oldSongName, oldGroup string, song *models.Song, link *models.SongLink) error {
+	defer metrics.ObserveQuery("song", "UpdateSong", time.Now())
+
+	r.Logger.InfoContext(ctx, "Updating song", "old_song_name", oldSongName, "old_group", oldGroup, "group", song.GroupName, "song", song.SongName)
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback()
 
 	query := `
 		UPDATE songs
-		SET group_name = $1, song_name = $2, text = $3, release_date = $6, link = $7
-		WHERE song_name = $4 AND group_name = $5
+		SET group_name = $1, song_name = $2, text = $3, release_date = $6, link = $7,
+		    duration_ms = $8, isrc = NULLIF($9, ''), bpm = $10, musical_key = $11, explicit = $12, explicit_lines = $13,
+		    language = $14, language_confidence = $15, language_manual = $16
+		FROM (SELECT id, link FROM songs WHERE song_name = $4 AND group_name = $5 FOR UPDATE) AS old
+		WHERE songs.id = old.id
+		RETURNING songs.id, songs.created_at, old.link
 	`
-	result, err := r.DB.Exec(query, req.NewGroup, req.NewSongName, req.NewText, req.OldSongName, req.OldGroup, req.NewReleaseDate, req.NewLink)
+	var oldLink string
+	err = tx.QueryRowContext(ctx, query, song.GroupName, song.SongName, song.Text, oldSongName, oldGroup, song.ReleaseDate, song.Link,
+		song.DurationMs, song.ISRC, song.BPM, song.Key, song.Explicit, pq.Array(explicitLines(song.ExplicitLines)),
+		song.Language, song.LanguageConfidence, song.LanguageManual).Scan(&song.ID, &song.CreatedAt, &oldLink)
 	if err != nil {
-		log.Printf("[ERROR] Failed to update song: %v", err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			r.Logger.InfoContext(ctx, "No song found", "old_song_name", oldSongName)
+		case isUniqueViolation(err, "idx_songs_isrc"):
+			r.Logger.InfoContext(ctx, "ISRC already exists", "isrc", song.ISRC)
+			return ErrDuplicateISRC
+		default:
+			r.Logger.ErrorContext(ctx, "Failed to update song", "error", err)
+		}
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		log.Printf("[ERROR] Failed to get rows affected: %v", err)
+	if err := syncSongLink(ctx, r.Logger, tx, song.ID, oldLink, link); err != nil {
+		return err
+	}
+	if err := syncPrimaryCredit(ctx, r.Logger, tx, song.ID, oldGroup, song.GroupName); err != nil {
 		return err
 	}
 
-	if rowsAffected == 0 {
-		log.Printf("[INFO] No song found with name: %s", req.OldSongName)
-		return sql.ErrNoRows
+	if err := tx.Commit(); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to commit transaction", "error", err)
+		return err
 	}
 
-	log.Printf("[INFO] Song updated successfully: oldName=%s, oldGroup=%s", req.OldSongName, req.OldGroup)
+	r.Logger.InfoContext(ctx, "Song updated successfully", "old_song_name", oldSongName, "old_group", oldGroup)
 	return nil
 }
 
-// FindSongs фильтрует и возвращает песни с учетом параметров пагинации
-func (r *SongRepositorySqlDbImpl) FindSongs(params models.FilterParams) ([]*models.Song, error) {
-	log.Printf("[INFO] Fetching songs with filters: %+v", params)
+// syncSongLink переносит ссылку из столбца songs.link в song_links: удаляет прежнюю ссылку oldURL,
+// если она заменена, и сохраняет link, обновляя провайдера и идентификатор уже существующей записи
+func syncSongLink(ctx context.Context, logger *slog.Logger, tx *sql.Tx, songID, oldURL string, link *models.SongLink) error {
+	if oldURL != "" && (link == nil || link.URL != oldURL) {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM song_links WHERE song_id = $1 AND url = $2", songID, oldURL); err != nil {
+			logger.ErrorContext(ctx, "Failed to delete previous song link", "error", err)
+			return err
+		}
+	}
+	if link == nil {
+		return nil
+	}
 
 	query := `
-		SELECT id, group_name, song_name, text, created_at, release_date, link
-		FROM songs
-		WHERE ($1 = '' OR group_name ILIKE '%' || $1 || '%')
-		  AND ($2 = '' OR song_name ILIKE '%' || $2 || '%')
-		  AND ($3 = '' OR text ILIKE '%' || $3 || '%')
-		  AND ($4 = '' OR release_date <= $4)
-		LIMIT $5 OFFSET $6
+		INSERT INTO song_links (song_id, provider, url, external_id, region)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (song_id, url) DO UPDATE
+		SET provider = EXCLUDED.provider, external_id = EXCLUDED.external_id, region = EXCLUDED.region
+		RETURNING id
 	`
+	if err := tx.QueryRowContext(ctx, query, songID, link.Provider, link.URL, link.ExternalID, link.Region).Scan(&link.ID); err != nil {
+		logger.ErrorContext(ctx, "Failed to save song link", "error", err)
+		return err
+	}
+	return nil
+}
 
-	rows, err := r.DB.Query(query, params.Group, params.SongName, params.Text, params.ReleaseDate, params.Limit, params.Offset)
+// FindSongs фильтрует и возвращает песни с учетом параметров пагинации. Песни упорядочены
+// по группе, названию и id, чтобы страницы не пересекались и не пропускали песни
+func (r *SongRepositorySqlDbImpl) FindSongs(ctx context.Context, params models.FilterParams) ([]*models.Song, error) {
+	defer metrics.ObserveQuery("song", "FindSongs", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching songs with filters", "params", params)
+
+	filter := buildSongFilter(params)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM songs
+		%s
+		ORDER BY group_name, song_name, id
+		LIMIT %s OFFSET %s
+	`, songColumns, filter.where(), filter.arg(params.Limit), filter.arg(params.Offset))
+
+	rows, err := r.DB.QueryContext(ctx, query, filter.args...)
 	if err != nil {
-		log.Printf("[ERROR] Failed to execute query: %v", err)
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
 		return nil, err
 	}
 	defer rows.Close()
 
 	var songs []*models.Song
 	for rows.Next() {
-		song := &models.Song{}
-		if err := rows.Scan(&song.ID, &song.GroupName, &song.SongName, &song.Text, &song.CreatedAt, &song.ReleaseDate, &song.Link); err != nil {
-			log.Printf("[ERROR] Failed to scan row: %v", err)
+		song, err := scanSong(rows)
+		if err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
 			return nil, err
 		}
 		songs = append(songs, song)
 	}
 
-	log.Printf("[INFO] Found %d songs", len(songs))
-	return songs, nil
+	r.Logger.InfoContext(ctx, "Found songs", "count", len(songs))
+	return songs, rows.Err()
+}
+
+// SampleSongs считает песни, подходящие под фильтры, и возвращает песни, стоящие на позициях (с нуля),
+// которые pick выбирает по их количеству, в упорядоченном по id результате фильтрации. Песни возвращаются
+// в порядке позиций. Подсчёт и выборка выполняются в одной транзакции REPEATABLE READ, поэтому песня,
+// удалённая между ними, не уменьшит выдачу, а все позиции выбираются за один проход по результату фильтрации
+func (r *SongRepositorySqlDbImpl) SampleSongs(ctx context.Context, params models.FilterParams, pick func(total int) []int64) (int, []*models.Song, error) {
+	defer metrics.ObserveQuery("song", "SampleSongs", time.Now())
+
+	r.Logger.InfoContext(ctx, "Sampling songs with filters", "params", params)
+
+	tx, err := r.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to begin transaction", "error", err)
+		return 0, nil, err
+	}
+	defer tx.Rollback()
+
+	filter := buildSongFilter(params)
+	var total int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM songs "+filter.where(), filter.args...).Scan(&total); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to count songs", "error", err)
+		return 0, nil, err
+	}
+	songs := []*models.Song{}
+	if total == 0 {
+		return total, songs, nil
+	}
+
+	positions := pick(total)
+	positionsArg := filter.arg(pq.Array(positions))
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM (SELECT songs.*, row_number() OVER (ORDER BY id) - 1 AS position
+		      FROM songs
+		      %s) numbered
+		JOIN unnest(%s::bigint[]) WITH ORDINALITY AS picked(pos, ord) ON picked.pos = numbered.position
+		ORDER BY picked.ord
+	`, songColumns, filter.where(), positionsArg)
+
+	rows, err := tx.QueryContext(ctx, query, filter.args...)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		song, err := scanSong(rows)
+		if err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return 0, nil, err
+		}
+		songs = append(songs, song)
+	}
+	if err := rows.Err(); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to read rows", "error", err)
+		return 0, nil, err
+	}
+
+	r.Logger.InfoContext(ctx, "Sampled songs", "count", len(songs), "total", total)
+	return total, songs, nil
+}
+
+// FindSongsReleasedOn возвращает песни, вышедшие в указанные месяц и день любого года, от старых к новым.
+// Поддерживаются даты релиза в форматах 2006-07-16 и 16.07.2006
+func (r *SongRepositorySqlDbImpl) FindSongsReleasedOn(ctx context.Context, month, day, limit, offset int) ([]*models.Song, error) {
+	defer metrics.ObserveQuery("song", "FindSongsReleasedOn", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching songs released on date", "month", month, "day", day)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM songs
+		WHERE (release_date ~ '^\d{4}-\d{2}-\d{2}'
+		       AND substring(release_date, 6, 2) = $1 AND substring(release_date, 9, 2) = $2)
+		   OR (release_date ~ '^\d{2}\.\d{2}\.\d{4}'
+		       AND substring(release_date, 4, 2) = $1 AND substring(release_date, 1, 2) = $2)
+		ORDER BY substring(release_date FROM '\d{4}'), group_name, song_name
+		LIMIT $3 OFFSET $4
+	`, songColumns)
+
+	// Месяц и день сравниваются как текст: PostgreSQL не гарантирует порядок вычисления условий AND,
+	// и приведение substring(...)::int могло бы выполниться до проверки формата даты
+	monthArg, dayArg := fmt.Sprintf("%02d", month), fmt.Sprintf("%02d", day)
+	rows, err := r.DB.QueryContext(ctx, query, monthArg, dayArg, limit, offset)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	songs := []*models.Song{}
+	for rows.Next() {
+		song, err := scanSong(rows)
+		if err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		songs = append(songs, song)
+	}
+
+	r.Logger.InfoContext(ctx, "Found songs released on date", "count", len(songs), "month", month, "day", day)
+	return songs, rows.Err()
 }
 
 // GetSongTextByNameAndGroup получает текст песни по названию
-func (r *SongRepositorySqlDbImpl) GetSongTextByNameAndGroup(songName, group string) (string, error) {
-	log.Printf("[INFO] Fetching song text for song: %s, group: %s", songName, group)
+func (r *SongRepositorySqlDbImpl) GetSongTextByNameAndGroup(ctx context.Context, songName, group string) (string, error) {
+	defer metrics.ObserveQuery("song", "GetSongTextByNameAndGroup", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching song text", "song", songName, "group", group)
 
 	query := "SELECT text FROM songs WHERE song_name = $1 AND group_name = $2"
 	var text string
-	err := r.DB.QueryRow(query, songName, group).Scan(&text)
+	err := r.DB.QueryRowContext(ctx, query, songName, group).Scan(&text)
 	if err != nil {
-		log.Printf("[ERROR] Failed to fetch song text: %v", err)
+		r.Logger.ErrorContext(ctx, "Failed to fetch song text", "error", err)
 		return "", err
 	}
 
-	log.Printf("[INFO] Successfully fetched text for song: %s", songName)
+	r.Logger.InfoContext(ctx, "Successfully fetched text", "song", songName)
 	return text, nil
 }
 
 // GetSongInfo получает информацию о песне по имени группы и названию песни
-func (r *SongRepositorySqlDbImpl) GetSongInfo(group, songName string) (*models.SongDetail, error) {
-	log.Printf("[INFO] Fetching song info for group: %s, song: %s", group, songName)
+func (r *SongRepositorySqlDbImpl) GetSongInfo(ctx context.Context, group, songName string) (*models.SongDetail, error) {
+	defer metrics.ObserveQuery("song", "GetSongInfo", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching song info", "group", group, "song", songName)
 
 	query := `
-		SELECT release_date, text, link
+		SELECT release_date, text, link, duration_ms, COALESCE(isrc, ''), bpm, musical_key, explicit, explicit_lines,
+		       language, language_confidence,
+		       ARRAY(SELECT g.name FROM song_genres sg JOIN genres g ON g.id = sg.genre_id
+		             WHERE sg.song_id = songs.id ORDER BY g.name),
+		       ARRAY(SELECT t.name FROM song_tags st JOIN tags t ON t.id = st.tag_id
+		             WHERE st.song_id = songs.id ORDER BY t.name),
+		       (SELECT COALESCE(json_agg(json_build_object('name', a.name, 'role', sc.role) ORDER BY sc.position), '[]')
+		        FROM song_credits sc JOIN artists a ON a.id = sc.artist_id
+		        WHERE sc.song_id = songs.id),
+		       (SELECT COALESCE(json_object_agg(grouped.provider, grouped.links), '{}')
+		        FROM (SELECT sl.provider,
+		                     json_agg(json_build_object('id', sl.id, 'provider', sl.provider, 'url', sl.url,
+		                                                'external_id', sl.external_id, 'region', sl.region,
+		                                                'status', sl.status, 'status_code', sl.status_code,
+		                                                'last_checked_at', sl.last_checked_at AT TIME ZONE 'UTC',
+		                                                'failure_streak', sl.failure_streak) ORDER BY sl.id) AS links
+		              FROM song_links sl
+		              WHERE sl.song_id = songs.id
+		              GROUP BY sl.provider) grouped)
 		FROM songs
 		WHERE group_name = $1 AND song_name = $2
 	`
 
 	var songDetail models.SongDetail
-	err := r.DB.QueryRow(query, group, songName).Scan(&songDetail.ReleaseDate, &songDetail.Text, &songDetail.Link)
+	var credits, links []byte
+	err := r.DB.QueryRowContext(ctx, query, group, songName).Scan(
+		&songDetail.ReleaseDate, &songDetail.Text, &songDetail.Link,
+		&songDetail.DurationMs, &songDetail.ISRC, &songDetail.BPM, &songDetail.Key, &songDetail.Explicit, pq.Array(&songDetail.ExplicitLines),
+		&songDetail.Language, &songDetail.LanguageConfidence,
+		pq.Array(&songDetail.Genres), pq.Array(&songDetail.Tags), &credits, &links,
+	)
 	if err != nil {
-		log.Printf("[ERROR] Failed to fetch song info: %v", err)
+		r.Logger.ErrorContext(ctx, "Failed to fetch song info", "error", err)
 		return nil, err
 	}
 
-	log.Printf("[INFO] Successfully fetched song info: %s", songName)
+	if err := json.Unmarshal(credits, &songDetail.Credits); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to decode song credits", "error", err)
+		return nil, err
+	}
+	if err := json.Unmarshal(links, &songDetail.Links); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to decode song links", "error", err)
+		return nil, err
+	}
+
+	r.Logger.InfoContext(ctx, "Successfully fetched song info", "song", songName)
 	return &songDetail, nil
 }
+
+// GetSongByID получает песню по её идентификатору
+func (r *SongRepositorySqlDbImpl) GetSongByID(ctx context.Context, id string) (*models.Song, error) {
+	defer metrics.ObserveQuery("song", "GetSongByID", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching song", "id", id)
+
+	query := fmt.Sprintf("SELECT %s FROM songs WHERE id = $1", songColumns)
+	song, err := scanSong(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to fetch song", "error", err)
+		return nil, err
+	}
+	return song, nil
+}
+
+// GetSongsByGroup возвращает все песни группы с точным совпадением названия
+func (r *SongRepositorySqlDbImpl) GetSongsByGroup(ctx context.Context, group string) ([]*models.Song, error) {
+	defer metrics.ObserveQuery("song", "GetSongsByGroup", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching songs", "group", group)
+
+	query := fmt.Sprintf("SELECT %s FROM songs WHERE group_name = $1 ORDER BY song_name", songColumns)
+	rows, err := r.DB.QueryContext(ctx, query, group)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var songs []*models.Song
+	for rows.Next() {
+		song, err := scanSong(rows)
+		if err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		songs = append(songs, song)
+	}
+
+	r.Logger.InfoContext(ctx, "Found songs", "count", len(songs), "group", group)
+	return songs, rows.Err()
+}
+
+// ListSongs возвращает порцию песен, упорядоченных по id, начиная после afterID
+func (r *SongRepositorySqlDbImpl) ListSongs(ctx context.Context, afterID string, limit int) ([]*models.Song, error) {
+	defer metrics.ObserveQuery("song", "ListSongs", time.Now())
+
+	r.Logger.DebugContext(ctx, "Listing songs", "limit", limit, "after_id", afterID)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM songs
+		WHERE $1 = '' OR id > $1::uuid
+		ORDER BY id
+		LIMIT $2
+	`, songColumns)
+
+	rows, err := r.DB.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var songs []*models.Song
+	for rows.Next() {
+		song, err := scanSong(rows)
+		if err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		songs = append(songs, song)
+	}
+	return songs, rows.Err()
+}
+
+// GetSongsSharingGenre возвращает идентификаторы песен, у которых есть общий жанр с песней id
+func (r *SongRepositorySqlDbImpl) GetSongsSharingGenre(ctx context.Context, id string) ([]string, error) {
+	defer metrics.ObserveQuery("song", "GetSongsSharingGenre", time.Now())
+
+	r.Logger.DebugContext(ctx, "Fetching songs sharing a genre", "id", id)
+
+	query := `
+		SELECT DISTINCT other.song_id
+		FROM song_genres own
+		JOIN song_genres other ON other.genre_id = own.genre_id AND other.song_id <> own.song_id
+		WHERE own.song_id = $1
+	`
+	rows, err := r.DB.QueryContext(ctx, query, id)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to fetch songs sharing a genre", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var songID string
+		if err := rows.Scan(&songID); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		ids = append(ids, songID)
+	}
+	return ids, rows.Err()
+}
+
+// GetSongsWithoutLanguage возвращает песни без определённого языка, упорядоченные по id.
+// afterID позволяет продолжить обход с места остановки
+func (r *SongRepositorySqlDbImpl) GetSongsWithoutLanguage(ctx context.Context, afterID string, limit int) ([]*models.Song, error) {
+	defer metrics.ObserveQuery("song", "GetSongsWithoutLanguage", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching songs without language", "limit", limit, "after_id", afterID)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM songs
+		WHERE language = '' AND NOT language_manual AND ($1 = '' OR id > $1::uuid)
+		ORDER BY id
+		LIMIT $2
+	`, songColumns)
+
+	rows, err := r.DB.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var songs []*models.Song
+	for rows.Next() {
+		song, err := scanSong(rows)
+		if err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		songs = append(songs, song)
+	}
+
+	r.Logger.InfoContext(ctx, "Found songs without language", "count", len(songs))
+	return songs, rows.Err()
+}
+
+// UpdateSongLanguage сохраняет автоматически определённый язык, не затрагивая заданный вручную
+func (r *SongRepositorySqlDbImpl) UpdateSongLanguage(ctx context.Context, id string, language models.LanguageInfo) error {
+	defer metrics.ObserveQuery("song", "UpdateSongLanguage", time.Now())
+
+	r.Logger.DebugContext(ctx, "Updating song language", "id", id, "language", language.Language, "confidence", language.Confidence)
+
+	query := `
+		UPDATE songs
+		SET language = $2, language_confidence = $3
+		WHERE id = $1 AND NOT language_manual
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id, language.Language, language.Confidence); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to update song language", "error", err)
+		return err
+	}
+	return nil
+}
+
+// explicitLines заменяет nil пустым списком, чтобы не нарушать NOT NULL у explicit_lines
+func explicitLines(lines []int64) []int64 {
+	if lines == nil {
+		return []int64{}
+	}
+	return lines
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"song-libary/models"
+	"strings"
+	"testing"
+)
+
+func TestFindSongs(t *testing.T) {
+	errConnReset := errors.New("connection reset by peer")
+
+	tests := []struct {
+		name    string
+		rowsErr error
+		wantErr error
+	}{
+		{name: "all rows read"},
+		{name: "iteration error", rowsErr: errConnReset, wantErr: errConnReset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &recordingConn{
+				reply:   func(string) ([]string, [][]driver.Value) { return []string{"id"}, nil },
+				rowsErr: tt.rowsErr,
+			}
+			r := NewSongRepositorySqlDbImpl(sql.OpenDB(conn), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			_, err := r.FindSongs(context.Background(), models.FilterParams{Group: "Muse", Limit: 10, Offset: 20})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FindSongs() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(conn.statements) != 1 {
+				t.Fatalf("statements = %d, want 1", len(conn.statements))
+			}
+			// Без ORDER BY PostgreSQL не гарантирует порядок, и соседние страницы могут пересекаться
+			if query := conn.statements[0].query; !strings.Contains(query, "ORDER BY group_name, song_name, id LIMIT $2 OFFSET $3") {
+				t.Errorf("query = %q, want stable order before LIMIT", query)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"context"
+	"song-libary/models"
+)
+
+type StatsRepository interface {
+	GetCatalogueStats(ctx context.Context, newest int) (*models.CatalogueStats, error)
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"song-libary/metrics"
+	"song-libary/models"
+	"time"
+)
+
+type StatsRepositorySqlDbImpl struct {
+	DB     *sql.DB
+	Logger *slog.Logger
+}
+
+func NewStatsRepositorySqlDbImpl(db *sql.DB, logger *slog.Logger) *StatsRepositorySqlDbImpl {
+	return &StatsRepositorySqlDbImpl{DB: db, Logger: logger}
+}
+
+// GetCatalogueStats считает статистику библиотеки агрегатными запросами.
+// Запросы выполняются в одной транзакции только для чтения, чтобы все показатели относились к одному снимку данных
+func (r *StatsRepositorySqlDbImpl) GetCatalogueStats(ctx context.Context, newest int) (*models.CatalogueStats, error) {
+	defer metrics.ObserveQuery("stats", "GetCatalogueStats", time.Now())
+
+	r.Logger.InfoContext(ctx, "Calculating catalogue stats")
+
+	tx, err := r.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to begin transaction", "error", err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stats := &models.CatalogueStats{
+		SongsPerGroup: []models.GroupCount{},
+		SongsPerYear:  []models.PeriodCount{},
+		Newest:        []models.RecentSong{},
+	}
+
+	// Экранированные переводы строк считаются одним символом
+	summary := `
+		SELECT COUNT(*),
+		       COUNT(DISTINCT group_name),
+		       COALESCE(AVG(length(replace(text, '\n', E'\n'))), 0),
+		       COUNT(*) FILTER (WHERE COALESCE(link, '') = ''
+		                          AND NOT EXISTS (SELECT 1 FROM song_links sl WHERE sl.song_id = songs.id)),
+		       COUNT(*) FILTER (WHERE btrim(text) = ''),
+		       COUNT(*) FILTER (WHERE COALESCE(release_date, '') = '')
+		FROM songs
+	`
+	err = tx.QueryRowContext(ctx, summary).Scan(&stats.Songs, &stats.Groups, &stats.AvgTextLength,
+		&stats.Missing.Link, &stats.Missing.Text, &stats.Missing.ReleaseDate)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to calculate summary stats", "error", err)
+		return nil, err
+	}
+
+	groups := `
+		SELECT group_name, COUNT(*)
+		FROM songs
+		GROUP BY group_name
+		ORDER BY COUNT(*) DESC, group_name
+	`
+	if err := queryRows(ctx, tx, groups, func(rows *sql.Rows) error {
+		var count models.GroupCount
+		if err := rows.Scan(&count.Group, &count.Songs); err != nil {
+			return err
+		}
+		stats.SongsPerGroup = append(stats.SongsPerGroup, count)
+		return nil
+	}); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to count songs per group", "error", err)
+		return nil, err
+	}
+
+	// Год берётся из первой четырёхзначной группы цифр, что подходит и для 2006-07-16, и для 16.07.2006
+	years := `
+		SELECT substring(release_date FROM '\d{4}')::int AS year, COUNT(*)
+		FROM songs
+		WHERE release_date ~ '\d{4}'
+		GROUP BY year
+		ORDER BY year
+	`
+	if err := queryRows(ctx, tx, years, func(rows *sql.Rows) error {
+		var count models.PeriodCount
+		if err := rows.Scan(&count.Period, &count.Songs); err != nil {
+			return err
+		}
+		stats.SongsPerYear = append(stats.SongsPerYear, count)
+		return nil
+	}); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to count songs per year", "error", err)
+		return nil, err
+	}
+
+	recent := `
+		SELECT id, group_name, song_name, created_at
+		FROM songs
+		ORDER BY created_at DESC NULLS LAST, id
+		LIMIT $1
+	`
+	if err := queryRows(ctx, tx, recent, func(rows *sql.Rows) error {
+		var song models.RecentSong
+		if err := rows.Scan(&song.ID, &song.Group, &song.SongName, &song.CreatedAt); err != nil {
+			return err
+		}
+		stats.Newest = append(stats.Newest, song)
+		return nil
+	}, newest); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to fetch newest songs", "error", err)
+		return nil, err
+	}
+
+	r.Logger.InfoContext(ctx, "Catalogue stats calculated", "songs", stats.Songs, "groups", stats.Groups)
+	return stats, nil
+}
+
+// queryRows выполняет запрос в транзакции и вызывает scan для каждой строки результата
+func queryRows(ctx context.Context, tx *sql.Tx, query string, scan func(rows *sql.Rows) error, args ...any) error {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"song-libary/models"
+)
+
+// ErrGenreNotFound возвращается, если жанр отсутствует в справочнике
+var ErrGenreNotFound = errors.New("genre not found")
+
+// ErrDuplicateGenre возвращается при попытке добавить жанр, который уже есть в справочнике
+var ErrDuplicateGenre = errors.New("genre already exists")
+
+type TagRepository interface {
+	CreateGenre(ctx context.Context, name, parent string) (*models.Genre, error)
+	GetGenres(ctx context.Context) ([]*models.Genre, error)
+	AddSongTags(ctx context.Context, songName, group string, genres, tags []string) error
+	RemoveSongTags(ctx context.Context, songName, group string, genres, tags []string) error
+}
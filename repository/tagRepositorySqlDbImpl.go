@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"log/slog"
+	"song-libary/metrics"
+	"song-libary/models"
+	"time"
+)
+
+type TagRepositorySqlDbImpl struct {
+	DB     *sql.DB
+	Logger *slog.Logger
+}
+
+func NewTagRepositorySqlDbImpl(db *sql.DB, logger *slog.Logger) *TagRepositorySqlDbImpl {
+	return &TagRepositorySqlDbImpl{DB: db, Logger: logger}
+}
+
+// CreateGenre добавляет жанр в справочник, при необходимости привязывая его к родителю
+func (r *TagRepositorySqlDbImpl) CreateGenre(ctx context.Context, name, parent string) (*models.Genre, error) {
+	defer metrics.ObserveQuery("tag", "CreateGenre", time.Now())
+
+	r.Logger.InfoContext(ctx, "Creating genre", "name", name, "parent", parent)
+
+	genre := &models.Genre{Name: name}
+	if parent != "" {
+		var parentID int
+		err := r.DB.QueryRowContext(ctx, "SELECT id FROM genres WHERE name = $1", parent).Scan(&parentID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				r.Logger.InfoContext(ctx, "Parent genre not found", "parent", parent)
+				return nil, ErrGenreNotFound
+			}
+			r.Logger.ErrorContext(ctx, "Failed to fetch parent genre", "error", err)
+			return nil, err
+		}
+		genre.ParentID = &parentID
+	}
+
+	query := "INSERT INTO genres (name, parent_id) VALUES ($1, $2) RETURNING id"
+	if err := r.DB.QueryRowContext(ctx, query, genre.Name, genre.ParentID).Scan(&genre.ID); err != nil {
+		if isUniqueViolation(err, "genres_name_key") {
+			r.Logger.InfoContext(ctx, "Genre already exists", "name", name)
+			return nil, ErrDuplicateGenre
+		}
+		r.Logger.ErrorContext(ctx, "Failed to create genre", "error", err)
+		return nil, err
+	}
+
+	r.Logger.InfoContext(ctx, "Genre created", "id", genre.ID)
+	return genre, nil
+}
+
+// GetGenres возвращает весь справочник жанров
+func (r *TagRepositorySqlDbImpl) GetGenres(ctx context.Context) ([]*models.Genre, error) {
+	defer metrics.ObserveQuery("tag", "GetGenres", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching genres")
+
+	rows, err := r.DB.QueryContext(ctx, "SELECT id, name, parent_id FROM genres ORDER BY name")
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var genres []*models.Genre
+	for rows.Next() {
+		genre := &models.Genre{}
+		if err := rows.Scan(&genre.ID, &genre.Name, &genre.ParentID); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		genres = append(genres, genre)
+	}
+
+	r.Logger.InfoContext(ctx, "Found genres", "count", len(genres))
+	return genres, rows.Err()
+}
+
+// AddSongTags привязывает к песне жанры из справочника и произвольные теги
+func (r *TagRepositorySqlDbImpl) AddSongTags(ctx context.Context, songName, group string, genres, tags []string) error {
+	defer metrics.ObserveQuery("tag", "AddSongTags", time.Now())
+
+	r.Logger.InfoContext(ctx, "Tagging song", "song", songName, "group", group, "genres", genres, "tags", tags)
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	songID, err := findSongID(ctx, r.Logger, tx, songName, group)
+	if err != nil {
+		return err
+	}
+
+	genreIDs, err := findGenreIDs(ctx, r.Logger, tx, genres)
+	if err != nil {
+		return err
+	}
+	for _, genreID := range genreIDs {
+		query := "INSERT INTO song_genres (song_id, genre_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+		if _, err := tx.ExecContext(ctx, query, songID, genreID); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to add genre to song", "error", err)
+			return err
+		}
+	}
+
+	for _, tag := range tags {
+		var tagID int
+		query := "INSERT INTO tags (name) VALUES ($1) ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id"
+		if err := tx.QueryRowContext(ctx, query, tag).Scan(&tagID); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to save tag", "error", err)
+			return err
+		}
+		query = "INSERT INTO song_tags (song_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+		if _, err := tx.ExecContext(ctx, query, songID, tagID); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to add tag to song", "error", err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to commit transaction", "error", err)
+		return err
+	}
+
+	r.Logger.InfoContext(ctx, "Song tagged successfully", "song", songName)
+	return nil
+}
+
+// RemoveSongTags отвязывает от песни указанные жанры и теги
+func (r *TagRepositorySqlDbImpl) RemoveSongTags(ctx context.Context, songName, group string, genres, tags []string) error {
+	defer metrics.ObserveQuery("tag", "RemoveSongTags", time.Now())
+
+	r.Logger.InfoContext(ctx, "Untagging song", "song", songName, "group", group, "genres", genres, "tags", tags)
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	songID, err := findSongID(ctx, r.Logger, tx, songName, group)
+	if err != nil {
+		return err
+	}
+
+	if len(genres) > 0 {
+		query := `
+			DELETE FROM song_genres
+			WHERE song_id = $1 AND genre_id IN (SELECT id FROM genres WHERE name = ANY($2))
+		`
+		if _, err := tx.ExecContext(ctx, query, songID, pq.Array(genres)); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to remove genres from song", "error", err)
+			return err
+		}
+	}
+
+	if len(tags) > 0 {
+		query := `
+			DELETE FROM song_tags
+			WHERE song_id = $1 AND tag_id IN (SELECT id FROM tags WHERE name = ANY($2))
+		`
+		if _, err := tx.ExecContext(ctx, query, songID, pq.Array(tags)); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to remove tags from song", "error", err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to commit transaction", "error", err)
+		return err
+	}
+
+	r.Logger.InfoContext(ctx, "Song untagged successfully", "song", songName)
+	return nil
+}
+
+// findSongID возвращает идентификатор песни по названию и группе внутри транзакции
+func findSongID(ctx context.Context, logger *slog.Logger, tx *sql.Tx, songName, group string) (string, error) {
+	var songID string
+	err := tx.QueryRowContext(ctx, "SELECT id FROM songs WHERE song_name = $1 AND group_name = $2", songName, group).Scan(&songID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.InfoContext(ctx, "No song found", "song", songName)
+		} else {
+			logger.ErrorContext(ctx, "Failed to fetch song id", "error", err)
+		}
+		return "", err
+	}
+	return songID, nil
+}
+
+// findGenreIDs возвращает идентификаторы жанров по названиям; отсутствующий жанр считается ошибкой
+func findGenreIDs(ctx context.Context, logger *slog.Logger, tx *sql.Tx, genres []string) ([]int, error) {
+	ids := make([]int, 0, len(genres))
+	for _, name := range genres {
+		var id int
+		err := tx.QueryRowContext(ctx, "SELECT id FROM genres WHERE name = $1", name).Scan(&id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				logger.InfoContext(ctx, "Genre not found", "name", name)
+				return nil, fmt.Errorf("%w: %s", ErrGenreNotFound, name)
+			}
+			logger.ErrorContext(ctx, "Failed to fetch genre", "error", err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
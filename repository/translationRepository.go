@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"song-libary/models"
+)
+
+// ErrDuplicateTranslation возвращается, если перевод на этот язык уже существует
+var ErrDuplicateTranslation = errors.New("translation already exists")
+
+type TranslationRepository interface {
+	SaveTranslation(ctx context.Context, songName, group string, translation *models.Translation) error
+	UpdateTranslation(ctx context.Context, songName, group string, translation *models.Translation) error
+	DeleteTranslation(ctx context.Context, songName, group, lang string) error
+	GetTranslations(ctx context.Context, songName, group string) ([]*models.Translation, error)
+	GetTranslation(ctx context.Context, songName, group, lang string) (*models.Translation, error)
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"song-libary/metrics"
+	"song-libary/models"
+	"time"
+)
+
+type TranslationRepositorySqlDbImpl struct {
+	DB     *sql.DB
+	Logger *slog.Logger
+}
+
+func NewTranslationRepositorySqlDbImpl(db *sql.DB, logger *slog.Logger) *TranslationRepositorySqlDbImpl {
+	return &TranslationRepositorySqlDbImpl{DB: db, Logger: logger}
+}
+
+// SaveTranslation сохраняет новый перевод песни
+func (r *TranslationRepositorySqlDbImpl) SaveTranslation(ctx context.Context, songName, group string, translation *models.Translation) error {
+	defer metrics.ObserveQuery("translation", "SaveTranslation", time.Now())
+
+	r.Logger.InfoContext(ctx, "Saving translation", "lang", translation.Lang, "song", songName, "group", group)
+
+	query := `
+		INSERT INTO song_translations (song_id, lang, text, translator, status)
+		SELECT id, $3, $4, $5, $6 FROM songs WHERE song_name = $1 AND group_name = $2
+		RETURNING id, created_at, updated_at
+	`
+	err := r.DB.QueryRowContext(ctx, query, songName, group, translation.Lang, translation.Text, translation.Translator, translation.Status).
+		Scan(&translation.ID, &translation.CreatedAt, &translation.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err, "song_translations_song_id_lang_key") {
+			r.Logger.InfoContext(ctx, "Translation already exists", "lang", translation.Lang)
+			return ErrDuplicateTranslation
+		}
+		r.Logger.ErrorContext(ctx, "Failed to save translation", "error", err)
+		return err
+	}
+
+	r.Logger.InfoContext(ctx, "Translation saved", "id", translation.ID)
+	return nil
+}
+
+// UpdateTranslation изменяет текст, автора и статус перевода
+func (r *TranslationRepositorySqlDbImpl) UpdateTranslation(ctx context.Context, songName, group string, translation *models.Translation) error {
+	defer metrics.ObserveQuery("translation", "UpdateTranslation", time.Now())
+
+	r.Logger.InfoContext(ctx, "Updating translation", "lang", translation.Lang, "song", songName, "group", group)
+
+	query := `
+		UPDATE song_translations
+		SET text = $4, translator = $5, status = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE lang = $3 AND song_id = (SELECT id FROM songs WHERE song_name = $1 AND group_name = $2)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.DB.QueryRowContext(ctx, query, songName, group, translation.Lang, translation.Text, translation.Translator, translation.Status).
+		Scan(&translation.ID, &translation.CreatedAt, &translation.UpdatedAt)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to update translation", "error", err)
+		return err
+	}
+
+	r.Logger.InfoContext(ctx, "Translation updated successfully", "id", translation.ID)
+	return nil
+}
+
+// DeleteTranslation удаляет перевод песни на указанный язык
+func (r *TranslationRepositorySqlDbImpl) DeleteTranslation(ctx context.Context, songName, group, lang string) error {
+	defer metrics.ObserveQuery("translation", "DeleteTranslation", time.Now())
+
+	r.Logger.InfoContext(ctx, "Deleting translation", "lang", lang, "song", songName, "group", group)
+
+	query := `
+		DELETE FROM song_translations
+		WHERE lang = $3 AND song_id = (SELECT id FROM songs WHERE song_name = $1 AND group_name = $2)
+	`
+	result, err := r.DB.ExecContext(ctx, query, songName, group, lang)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to delete translation", "error", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to get rows affected", "error", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		r.Logger.InfoContext(ctx, "No translation found", "lang", lang, "song", songName)
+		return sql.ErrNoRows
+	}
+
+	r.Logger.InfoContext(ctx, "Translation deleted successfully", "lang", lang)
+	return nil
+}
+
+// GetTranslations возвращает все переводы песни
+func (r *TranslationRepositorySqlDbImpl) GetTranslations(ctx context.Context, songName, group string) ([]*models.Translation, error) {
+	defer metrics.ObserveQuery("translation", "GetTranslations", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching translations", "song", songName, "group", group)
+
+	var songID string
+	err := r.DB.QueryRowContext(ctx, "SELECT id FROM songs WHERE song_name = $1 AND group_name = $2", songName, group).Scan(&songID)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to fetch song id", "error", err)
+		return nil, err
+	}
+
+	query := `
+		SELECT id, lang, text, translator, status, created_at, updated_at
+		FROM song_translations
+		WHERE song_id = $1
+		ORDER BY lang
+	`
+	rows, err := r.DB.QueryContext(ctx, query, songID)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to execute query", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := []*models.Translation{}
+	for rows.Next() {
+		translation := &models.Translation{}
+		if err := rows.Scan(&translation.ID, &translation.Lang, &translation.Text, &translation.Translator,
+			&translation.Status, &translation.CreatedAt, &translation.UpdatedAt); err != nil {
+			r.Logger.ErrorContext(ctx, "Failed to scan row", "error", err)
+			return nil, err
+		}
+		translations = append(translations, translation)
+	}
+
+	r.Logger.InfoContext(ctx, "Found translations", "count", len(translations), "song", songName)
+	return translations, rows.Err()
+}
+
+// GetTranslation возвращает перевод песни на указанный язык
+func (r *TranslationRepositorySqlDbImpl) GetTranslation(ctx context.Context, songName, group, lang string) (*models.Translation, error) {
+	defer metrics.ObserveQuery("translation", "GetTranslation", time.Now())
+
+	r.Logger.InfoContext(ctx, "Fetching translation", "lang", lang, "song", songName, "group", group)
+
+	query := `
+		SELECT t.id, t.lang, t.text, t.translator, t.status, t.created_at, t.updated_at
+		FROM song_translations t JOIN songs s ON s.id = t.song_id
+		WHERE s.song_name = $1 AND s.group_name = $2 AND t.lang = $3
+	`
+	translation := &models.Translation{}
+	err := r.DB.QueryRowContext(ctx, query, songName, group, lang).Scan(&translation.ID, &translation.Lang, &translation.Text,
+		&translation.Translator, &translation.Status, &translation.CreatedAt, &translation.UpdatedAt)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "Failed to fetch translation", "error", err)
+		return nil, err
+	}
+
+	return translation, nil
+}
@@ -0,0 +1,139 @@
+// Package router регистрирует маршруты в http.ServeMux шаблонами Go 1.22 с методом
+// ("GET /songs/{id}/stats"). Маршруты объединяются в группы с общим префиксом и цепочкой
+// middleware. Для пути, зарегистрированного хотя бы с одним методом, запрос другим методом
+// получает 405 с заголовком Allow, а OPTIONS — 204 с тем же заголовком. HEAD обрабатывается
+// обработчиком GET.
+package router
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Middleware оборачивает обработчик маршрута
+type Middleware func(next http.Handler) http.Handler
+
+// Router регистрирует маршруты с префиксом группы и её middleware
+type Router struct {
+	routes     *routes
+	prefix     string
+	middleware []Middleware
+}
+
+// routes — состояние, общее для корневого маршрутизатора и всех групп
+type routes struct {
+	mux              *http.ServeMux
+	methods          map[string][]string // Методы, зарегистрированные для пути
+	methodNotAllowed http.Handler
+}
+
+// New создает маршрутизатор. notFound отвечает на запросы к незарегистрированным путям,
+// methodNotAllowed — на запросы неподдерживаемым методом, заголовок Allow к этому моменту
+// уже установлен; nil — ответы http.ServeMux по умолчанию
+func New(notFound, methodNotAllowed http.Handler) *Router {
+	if methodNotAllowed == nil {
+		methodNotAllowed = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		})
+	}
+	rt := &Router{routes: &routes{
+		mux:              http.NewServeMux(),
+		methods:          make(map[string][]string),
+		methodNotAllowed: methodNotAllowed,
+	}}
+	if notFound != nil {
+		rt.routes.mux.Handle("/", notFound)
+	}
+	return rt
+}
+
+// Use добавляет middleware к маршрутам, которые будут зарегистрированы в группе позже.
+// Первый добавленный middleware выполняется первым
+func (rt *Router) Use(middleware ...Middleware) {
+	rt.middleware = append(rt.middleware, middleware...)
+}
+
+// Group возвращает группу маршрутов с префиксом prefix, наследующую middleware группы rt
+func (rt *Router) Group(prefix string) *Router {
+	return &Router{
+		routes:     rt.routes,
+		prefix:     rt.prefix + prefix,
+		middleware: slices.Clone(rt.middleware),
+	}
+}
+
+// Handle регистрирует обработчик пути path для метода method
+func (rt *Router) Handle(method, path string, handler http.Handler) {
+	path = rt.prefix + path
+	for i := len(rt.middleware) - 1; i >= 0; i-- {
+		handler = rt.middleware[i](handler)
+	}
+	rt.routes.mux.Handle(method+" "+path, handler)
+
+	if _, ok := rt.routes.methods[path]; !ok {
+		rt.routes.mux.Handle(path, rt.routes.fallback(path))
+	}
+	rt.routes.methods[path] = append(rt.routes.methods[path], method)
+}
+
+// Get регистрирует обработчик GET-запросов; HEAD-запросы обрабатываются им же
+func (rt *Router) Get(path string, handler http.HandlerFunc) {
+	rt.Handle(http.MethodGet, path, handler)
+}
+
+// Post регистрирует обработчик POST-запросов
+func (rt *Router) Post(path string, handler http.HandlerFunc) {
+	rt.Handle(http.MethodPost, path, handler)
+}
+
+// Put регистрирует обработчик PUT-запросов
+func (rt *Router) Put(path string, handler http.HandlerFunc) {
+	rt.Handle(http.MethodPut, path, handler)
+}
+
+// Delete регистрирует обработчик DELETE-запросов
+func (rt *Router) Delete(path string, handler http.HandlerFunc) {
+	rt.Handle(http.MethodDelete, path, handler)
+}
+
+// Mount передаёт обработчику handler все запросы с путём, начинающимся с prefix, любым методом.
+// Middleware группы не применяются
+func (rt *Router) Mount(prefix string, handler http.Handler) {
+	rt.routes.mux.Handle(rt.prefix+prefix, handler)
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt.routes.mux.ServeHTTP(w, r)
+}
+
+// fallback отвечает на запросы к пути path методами, для которых обработчик не зарегистрирован
+func (s *routes) fallback(path string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", strings.Join(s.allowed(path), ", "))
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		s.methodNotAllowed.ServeHTTP(w, r)
+	})
+}
+
+// allowed возвращает методы, которыми можно обратиться к пути path
+func (s *routes) allowed(path string) []string {
+	methods := slices.Clone(s.methods[path])
+	if slices.Contains(methods, http.MethodGet) && !slices.Contains(methods, http.MethodHead) {
+		methods = append(methods, http.MethodHead)
+	}
+	return append(methods, http.MethodOptions)
+}
+
+// Path возвращает шаблон пути маршрута, обработавшего запрос, без метода: "/songs/{id}/stats"
+// для "GET /songs/{id}/stats". Пустая строка — запрос не сопоставлен маршруту.
+// По этому значению выбираются настройки маршрута и подписываются метрики
+func Path(r *http.Request) string {
+	if _, path, ok := strings.Cut(r.Pattern, " "); ok {
+		return path
+	}
+	return r.Pattern
+}
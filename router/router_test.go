@@ -0,0 +1,160 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// respond возвращает обработчик, отвечающий телом body
+func respond(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}
+}
+
+func TestRouter(t *testing.T) {
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no route", http.StatusNotFound)
+	})
+	rt := New(notFound, nil)
+	rt.Get("/songs", respond("list"))
+	rt.Post("/songs", respond("create"))
+	rt.Put("/songs/{id}", respond("update"))
+	rt.Delete("/songs/{id}", respond("delete"))
+	rt.Get("/songs/{id}", respond("get"))
+	rt.Mount("/debug/", respond("debug"))
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		wantStatus int
+		wantBody   string
+		wantAllow  string
+	}{
+		{name: "get", method: http.MethodGet, target: "/songs", wantStatus: http.StatusOK, wantBody: "list"},
+		{name: "post", method: http.MethodPost, target: "/songs", wantStatus: http.StatusOK, wantBody: "create"},
+		{name: "wildcard", method: http.MethodPut, target: "/songs/42", wantStatus: http.StatusOK, wantBody: "update"},
+		// Тело HEAD-ответа отбрасывает http.Server, а не маршрутизатор
+		{name: "head served by get", method: http.MethodHead, target: "/songs", wantStatus: http.StatusOK, wantBody: "list"},
+		{
+			name:       "method not allowed",
+			method:     http.MethodPatch,
+			target:     "/songs",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "Method Not Allowed\n",
+			wantAllow:  "GET, POST, HEAD, OPTIONS",
+		},
+		{
+			name:       "method not allowed on wildcard path",
+			method:     http.MethodPost,
+			target:     "/songs/42",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "Method Not Allowed\n",
+			wantAllow:  "PUT, DELETE, GET, HEAD, OPTIONS",
+		},
+		{name: "options", method: http.MethodOptions, target: "/songs", wantStatus: http.StatusNoContent, wantAllow: "GET, POST, HEAD, OPTIONS"},
+		{name: "not found", method: http.MethodGet, target: "/albums", wantStatus: http.StatusNotFound, wantBody: "no route\n"},
+		{name: "mount any method", method: http.MethodPatch, target: "/debug/pprof/", wantStatus: http.StatusOK, wantBody: "debug"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			rt.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+			if got := w.Header().Get("Allow"); got != tt.wantAllow {
+				t.Errorf("Allow = %q, want %q", got, tt.wantAllow)
+			}
+		})
+	}
+}
+
+func TestRouterMethodNotAllowedHandler(t *testing.T) {
+	var allow string
+	rt := New(nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allow = w.Header().Get("Allow")
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	rt.Post("/songs", respond("create"))
+
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/songs", nil))
+
+	if w.Code != http.StatusTeapot {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusTeapot)
+	}
+	if allow != "POST, OPTIONS" {
+		t.Errorf("Allow seen by handler = %q, want %q", allow, "POST, OPTIONS")
+	}
+}
+
+func TestRouterGroups(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler "+Path(r))
+	}
+
+	rt := New(nil, nil)
+	rt.Use(trace("root"))
+	api := rt.Group("/api")
+	api.Use(trace("api"), trace("auth"))
+	api.Get("/songs/{id}", handler)
+	rt.Use(trace("late"))
+	rt.Get("/health", handler)
+	api.Group("/v2").Mount("/files/", http.HandlerFunc(handler))
+
+	tests := []struct {
+		name   string
+		target string
+		want   []string
+	}{
+		{name: "group prefix and middleware order", target: "/api/songs/42", want: []string{"root", "api", "auth", "handler /api/songs/{id}"}},
+		{name: "middleware added later applies to later routes", target: "/health", want: []string{"root", "late", "handler /health"}},
+		{name: "mount skips middleware", target: "/api/v2/files/a.txt", want: []string{"handler /api/v2/files/"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			w := httptest.NewRecorder()
+			rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if !slices.Equal(calls, tt.want) {
+				t.Errorf("calls = %q, want %q", calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: "GET /songs/{id}/stats", want: "/songs/{id}/stats"},
+		{pattern: "/debug/", want: "/debug/"},
+		{pattern: "", want: ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Pattern = tt.pattern
+		if got := Path(r); got != tt.want {
+			t.Errorf("Path(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
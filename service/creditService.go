@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"song-libary/models"
+	"song-libary/repository"
+	"strings"
+)
+
+var ErrInvalidCredit = Invalid("invalid_credit", "invalid credit")
+
+// maxArtistNameLength совпадает с размером столбца artists.name
+const maxArtistNameLength = 100
+
+// creditRoles перечисляет допустимые роли участников
+var creditRoles = map[string]bool{
+	models.CreditRolePrimary:  true,
+	models.CreditRoleFeatured: true,
+	models.CreditRoleComposer: true,
+	models.CreditRoleLyricist: true,
+	models.CreditRoleProducer: true,
+}
+
+type CreditService struct {
+	Repo   repository.CreditRepository
+	Logger *slog.Logger
+}
+
+func NewCreditService(repo repository.CreditRepository, logger *slog.Logger) *CreditService {
+	return &CreditService{Repo: repo, Logger: logger}
+}
+
+// GetCredits возвращает участников работы над песней
+func (s *CreditService) GetCredits(ctx context.Context, songName, group string) ([]models.Credit, error) {
+	s.Logger.InfoContext(ctx, "Fetching credits", "song", songName, "group", group)
+
+	credits, err := s.Repo.GetSongCredits(ctx, songName, group)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSongNotFound
+		}
+		s.Logger.ErrorContext(ctx, "Failed to fetch credits", "error", err)
+		return nil, err
+	}
+	return credits, nil
+}
+
+// SetCredits проверяет и сохраняет полный список участников работы над песней
+func (s *CreditService) SetCredits(ctx context.Context, req models.SetCreditsRequest) error {
+	s.Logger.InfoContext(ctx, "Setting credits", "song", req.Song, "group", req.Group)
+
+	credits, err := normalizeCredits(req.Credits)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Repo.SetSongCredits(ctx, req.Song, req.Group, credits); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrSongNotFound
+		}
+		s.Logger.ErrorContext(ctx, "Failed to set credits", "error", err)
+		return err
+	}
+
+	s.Logger.InfoContext(ctx, "Credits set successfully", "song", req.Song)
+	return nil
+}
+
+// normalizeCredits проверяет роли, убирает лишние пробелы и повторы
+func normalizeCredits(credits []models.Credit) ([]models.Credit, error) {
+	seen := make(map[models.Credit]bool, len(credits))
+	result := make([]models.Credit, 0, len(credits))
+	for _, credit := range credits {
+		credit.Name = strings.Join(strings.Fields(credit.Name), " ")
+		credit.Role = strings.ToLower(strings.TrimSpace(credit.Role))
+		if credit.Name == "" {
+			return nil, withField(fmt.Errorf("%w: name is required", ErrInvalidCredit), "credits")
+		}
+		if err := checkNameLength([]string{credit.Name}, maxArtistNameLength, ErrInvalidCredit); err != nil {
+			return nil, withField(fmt.Errorf("%w: name must be at most %d characters", err, maxArtistNameLength), "credits")
+		}
+		if !creditRoles[credit.Role] {
+			return nil, withField(fmt.Errorf("%w: unknown role %q", ErrInvalidCredit, credit.Role), "credits")
+		}
+
+		key := models.Credit{Name: strings.ToLower(credit.Name), Role: credit.Role}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, credit)
+	}
+	return result, nil
+}
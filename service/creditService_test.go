@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"song-libary/models"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeCredits(t *testing.T) {
+	tests := []struct {
+		name    string
+		credits []models.Credit
+		want    []models.Credit
+		err     bool
+	}{
+		{
+			name:    "spaces and case normalized",
+			credits: []models.Credit{{Name: "  Matthew   Bellamy ", Role: " Composer "}},
+			want:    []models.Credit{{Name: "Matthew Bellamy", Role: models.CreditRoleComposer}},
+		},
+		{
+			name: "repeats removed",
+			credits: []models.Credit{
+				{Name: "Matthew Bellamy", Role: "composer"},
+				{Name: "matthew bellamy", Role: "composer"},
+				{Name: "Matthew Bellamy", Role: "lyricist"},
+			},
+			want: []models.Credit{{Name: "Matthew Bellamy", Role: "composer"}, {Name: "Matthew Bellamy", Role: "lyricist"}},
+		},
+		{name: "name at limit", credits: []models.Credit{{Name: strings.Repeat("я", maxArtistNameLength), Role: "primary"}},
+			want: []models.Credit{{Name: strings.Repeat("я", maxArtistNameLength), Role: "primary"}}},
+		{name: "empty name", credits: []models.Credit{{Name: "  ", Role: "primary"}}, err: true},
+		{name: "name too long", credits: []models.Credit{{Name: strings.Repeat("я", maxArtistNameLength+1), Role: "primary"}}, err: true},
+		{name: "unknown role", credits: []models.Credit{{Name: "Muse", Role: "drummer"}}, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeCredits(tt.credits)
+			if tt.err {
+				var e *Error
+				if !errors.As(err, &e) || e.Code != ErrInvalidCredit.Code {
+					t.Fatalf("normalizeCredits() error = %v, want %v", err, ErrInvalidCredit)
+				}
+				if len(e.Fields) != 1 || e.Fields[0].Field != "credits" {
+					t.Errorf("fields = %+v, want credits", e.Fields)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeCredits() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("normalizeCredits() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetCreditsRejectsLongNameBeforeRepository(t *testing.T) {
+	// Репозиторий не нужен: слишком длинное имя отклоняется до обращения к базе данных
+	s := NewCreditService(nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	err := s.SetCredits(context.Background(), models.SetCreditsRequest{
+		Group: "Muse", Song: "Hysteria",
+		Credits: []models.Credit{{Name: strings.Repeat("a", maxArtistNameLength+1), Role: "composer"}},
+	})
+	if !errors.Is(err, ErrInvalidCredit) {
+		t.Errorf("SetCredits() error = %v, want %v", err, ErrInvalidCredit)
+	}
+}
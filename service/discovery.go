@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"math/rand/v2"
+	"song-libary/models"
+	"song-libary/tracing"
+	"time"
+)
+
+var (
+	ErrInvalidDate        = Invalid("invalid_date", "date must be in YYYY-MM-DD or MM-DD format")
+	ErrInvalidRandomCount = Invalid("invalid_count", "count must be between 1 and 50")
+)
+
+// Ограничения выдачи случайных песен
+const (
+	DefaultRandomCount = 1
+	MaxRandomCount     = 50
+)
+
+// GetSongsOnThisDay возвращает песни, вышедшие в тот же день и месяц, что и date, в любой год.
+// Пустая date означает сегодняшний день
+func (s *SongService) GetSongsOnThisDay(ctx context.Context, date string, limit, offset int) (_ []*models.Song, err error) {
+	ctx, span := tracing.Start(ctx, "SongService.GetSongsOnThisDay")
+	defer tracing.End(span, &err)
+
+	s.Logger.InfoContext(ctx, "Fetching songs released on this day", "date", date)
+
+	month, day, err := parseMonthDay(date)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.FindSongsReleasedOn(ctx, month, day, limit, offset)
+}
+
+// parseMonthDay извлекает месяц и день из даты YYYY-MM-DD или MM-DD
+func parseMonthDay(date string) (int, int, error) {
+	if date == "" {
+		now := time.Now()
+		return int(now.Month()), now.Day(), nil
+	}
+
+	parsed, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		// Високосный год позволяет принять 02-29
+		if parsed, err = time.Parse(time.DateOnly, "2000-"+date); err != nil {
+			return 0, 0, withField(ErrInvalidDate, "date")
+		}
+	}
+	return int(parsed.Month()), parsed.Day(), nil
+}
+
+// GetRandomSongs возвращает count случайных различных песен, подходящих под фильтры.
+// Каждая подходящая песня выбирается с равной вероятностью: сервис выбирает случайные позиции
+// по количеству подходящих песен, не сортируя всю таблицу через ORDER BY random().
+// При одинаковом seed и неизменных данных результат повторяется
+func (s *SongService) GetRandomSongs(ctx context.Context, params models.FilterParams, count int, seed *int64) (_ *models.RandomSongsResponse, err error) {
+	ctx, span := tracing.Start(ctx, "SongService.GetRandomSongs")
+	defer tracing.End(span, &err)
+
+	s.Logger.InfoContext(ctx, "Picking random songs", "count", count, "params", params)
+
+	if count < 1 || count > MaxRandomCount {
+		return nil, withField(ErrInvalidRandomCount, "count")
+	}
+	params, err = normalizeFilter(params)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.RandomSongsResponse{Songs: []*models.Song{}}
+	if seed != nil {
+		response.Seed = *seed
+	} else {
+		response.Seed = rand.Int64()
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(response.Seed), 0))
+	total, songs, err := s.Repo.SampleSongs(ctx, params, func(total int) []int64 {
+		return samplePositions(rng, total, count)
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Total = total
+	response.Songs = songs
+	return response, nil
+}
+
+// samplePositions выбирает min(k, n) различных позиций из [0, n) алгоритмом Флойда
+// и перемешивает их, чтобы порядок выдачи тоже был случайным
+func samplePositions(rng *rand.Rand, n, k int) []int64 {
+	k = min(k, n)
+	chosen := make(map[int64]bool, k)
+	positions := make([]int64, 0, k)
+	for j := n - k; j < n; j++ {
+		position := rng.Int64N(int64(j) + 1)
+		if chosen[position] {
+			position = int64(j)
+		}
+		chosen[position] = true
+		positions = append(positions, position)
+	}
+	rng.Shuffle(len(positions), func(i, j int) {
+		positions[i], positions[j] = positions[j], positions[i]
+	})
+	return positions
+}
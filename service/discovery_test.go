@@ -0,0 +1,84 @@
+package service
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSamplePositions(t *testing.T) {
+	tests := []struct {
+		name string
+		n, k int
+		want int
+	}{
+		{name: "fewer than available", n: 100, k: 10, want: 10},
+		{name: "all available", n: 5, k: 5, want: 5},
+		{name: "more than available", n: 3, k: 10, want: 3},
+		{name: "single song", n: 1, k: 1, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positions := samplePositions(rand.New(rand.NewPCG(42, 0)), tt.n, tt.k)
+			if len(positions) != tt.want {
+				t.Fatalf("len(positions) = %d, want %d", len(positions), tt.want)
+			}
+			seen := make(map[int64]bool, len(positions))
+			for _, position := range positions {
+				if position < 0 || position >= int64(tt.n) {
+					t.Errorf("position %d out of range [0, %d)", position, tt.n)
+				}
+				if seen[position] {
+					t.Errorf("position %d picked twice", position)
+				}
+				seen[position] = true
+			}
+		})
+	}
+}
+
+func TestSamplePositionsSeed(t *testing.T) {
+	first := samplePositions(rand.New(rand.NewPCG(7, 0)), 1000, 20)
+	second := samplePositions(rand.New(rand.NewPCG(7, 0)), 1000, 20)
+	if !slices.Equal(first, second) {
+		t.Errorf("same seed gave %v and %v", first, second)
+	}
+	if other := samplePositions(rand.New(rand.NewPCG(8, 0)), 1000, 20); slices.Equal(first, other) {
+		t.Errorf("different seeds gave the same positions %v", first)
+	}
+}
+
+func TestParseMonthDay(t *testing.T) {
+	tests := []struct {
+		date       string
+		month, day int
+		err        error
+	}{
+		{date: "07-16", month: 7, day: 16},
+		{date: "02-29", month: 2, day: 29},
+		{date: "2006-07-16", month: 7, day: 16},
+		{date: "2024-02-29", month: 2, day: 29},
+		{date: "2023-02-29", err: ErrInvalidDate},
+		{date: "13-01", err: ErrInvalidDate},
+		{date: "02-30", err: ErrInvalidDate},
+		{date: "7-16", err: ErrInvalidDate},
+		{date: "16.07.2006", err: ErrInvalidDate},
+		{date: "yesterday", err: ErrInvalidDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			month, day, err := parseMonthDay(tt.date)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("parseMonthDay(%q) error = %v, want %v", tt.date, err, tt.err)
+			}
+			if tt.err == nil && (month != tt.month || day != tt.day) {
+				t.Errorf("parseMonthDay(%q) = %d-%d, want %d-%d", tt.date, month, day, tt.month, tt.day)
+			}
+			var e *Error
+			if tt.err != nil && (!errors.As(err, &e) || len(e.Fields) != 1 || e.Fields[0].Field != "date") {
+				t.Errorf("parseMonthDay(%q) error fields = %+v, want date", tt.date, e)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math"
+	"regexp"
+	"slices"
+	"song-libary/metrics"
+	"song-libary/models"
+	"song-libary/repository"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrDuplicateCandidateNotFound = NotFound("duplicate_candidate_not_found", "duplicate candidate not found")
+	ErrInvalidDuplicateStatus     = Invalid("invalid_duplicate_status", "status must be either \"pending\" or \"dismissed\"")
+	ErrInvalidMerge               = Invalid("invalid_merge", "survivor_id and duplicate_id must be different song UUIDs")
+	ErrInvalidMergeField          = Invalid("invalid_merge_field", "unknown merge field")
+)
+
+// DefaultMinLyricSimilarity — минимальная близость текстов, при которой песни с одинаковыми названиями считаются дубликатами
+const DefaultMinLyricSimilarity = 0.8
+
+// mergeFields перечисляет поля, которые при слиянии можно взять у дубликата
+var mergeFields = map[string]bool{
+	models.MergeFieldGroup:       true,
+	models.MergeFieldSong:        true,
+	models.MergeFieldText:        true,
+	models.MergeFieldReleaseDate: true,
+	models.MergeFieldLink:        true,
+	models.MergeFieldDuration:    true,
+	models.MergeFieldISRC:        true,
+	models.MergeFieldBPM:         true,
+	models.MergeFieldKey:         true,
+}
+
+// versionSuffix убирает из названия уточнения версии: «Hysteria (Live)», «Song [Remastered]», «Song - Live»
+var versionSuffix = regexp.MustCompile(`\s*(\([^)]*\)|\[[^\]]*\]|\s-\s.*)`)
+
+type DuplicateService struct {
+	Repo          repository.DuplicateRepository
+	Songs         repository.SongRepository
+	Similarity    *SimilarityIndex
+	MinSimilarity float64
+	Logger        *slog.Logger
+}
+
+func NewDuplicateService(repo repository.DuplicateRepository, songs repository.SongRepository, similarity *SimilarityIndex, logger *slog.Logger) *DuplicateService {
+	return &DuplicateService{Repo: repo, Songs: songs, Similarity: similarity, MinSimilarity: DefaultMinLyricSimilarity, Logger: logger}
+}
+
+// ScanDuplicates ищет пары песен с одинаковыми нормализованными названием и группой и близкими текстами
+// и сохраняет их как кандидатов для проверки. Возвращает число новых кандидатов
+func (s *DuplicateService) ScanDuplicates(ctx context.Context) (int, error) {
+	s.Logger.InfoContext(ctx, "Scanning catalogue for duplicate songs")
+
+	buckets := make(map[string][]*models.Song)
+	afterID := ""
+	for {
+		songs, err := s.Songs.ListSongs(ctx, afterID, indexBatchSize)
+		if err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to load songs for duplicate scan", "error", err)
+			return 0, err
+		}
+		if len(songs) == 0 {
+			break
+		}
+		for _, song := range songs {
+			key := normalizeTitle(song.GroupName) + "\x00" + normalizeTitle(song.SongName)
+			buckets[key] = append(buckets[key], song)
+			afterID = song.ID
+		}
+	}
+
+	var candidates []models.DuplicateCandidate
+	for _, songs := range buckets {
+		for i := 0; i < len(songs); i++ {
+			for j := i + 1; j < len(songs); j++ {
+				similarity := lyricSimilarity(songs[i].Text, songs[j].Text)
+				if similarity < s.MinSimilarity {
+					continue
+				}
+				// Пара хранится в одном порядке, чтобы повторное сканирование не создавало её заново
+				first, second := songs[i], songs[j]
+				if first.ID > second.ID {
+					first, second = second, first
+				}
+				candidates = append(candidates, models.DuplicateCandidate{
+					SongID:      first.ID,
+					DuplicateID: second.ID,
+					Similarity:  similarity,
+				})
+			}
+		}
+	}
+
+	s.Logger.InfoContext(ctx, "Found duplicate pairs", "count", len(candidates))
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+	return s.Repo.SaveDuplicateCandidates(ctx, candidates)
+}
+
+// GetCandidates возвращает кандидатов в дубликаты; пустой status означает ожидающих проверки
+func (s *DuplicateService) GetCandidates(ctx context.Context, status string, limit, offset int) ([]models.DuplicateCandidate, error) {
+	s.Logger.InfoContext(ctx, "Fetching duplicate candidates", "status", status)
+
+	switch status {
+	case "":
+		status = models.DuplicateStatusPending
+	case models.DuplicateStatusPending, models.DuplicateStatusDismissed:
+	default:
+		return nil, ErrInvalidDuplicateStatus
+	}
+	return s.Repo.GetDuplicateCandidates(ctx, status, limit, offset)
+}
+
+// DismissCandidate отмечает пару как разные песни, чтобы она больше не предлагалась
+func (s *DuplicateService) DismissCandidate(ctx context.Context, id int) error {
+	if err := s.Repo.DismissDuplicateCandidate(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrDuplicateCandidateNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// MergeSongs объединяет дубликат с оставляемой песней и обновляет индекс похожих песен
+func (s *DuplicateService) MergeSongs(ctx context.Context, req models.MergeSongsRequest) (*models.Song, error) {
+	s.Logger.InfoContext(ctx, "Merging songs", "survivor_id", req.SurvivorID, "duplicate_id", req.DuplicateID)
+
+	if !uuidPattern.MatchString(req.SurvivorID) || !uuidPattern.MatchString(req.DuplicateID) ||
+		strings.EqualFold(req.SurvivorID, req.DuplicateID) {
+		return nil, ErrInvalidMerge
+	}
+	// Повторы полей убираются: в UPDATE столбец можно присвоить только один раз
+	fields := make([]string, 0, len(req.Fields))
+	for _, field := range req.Fields {
+		if !mergeFields[field] {
+			return nil, ErrInvalidMergeField
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+
+	survivor, err := s.Repo.MergeSongs(ctx, req.SurvivorID, req.DuplicateID, fields)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrSongNotFound
+		case errors.Is(err, repository.ErrDuplicateISRC):
+			return nil, ErrDuplicateISRC
+		}
+		s.Logger.ErrorContext(ctx, "Failed to merge songs", "error", err)
+		return nil, err
+	}
+
+	s.Similarity.Remove(req.DuplicateID)
+	s.Similarity.Upsert(survivor)
+
+	metrics.SongsMerged.Inc()
+	s.Logger.InfoContext(ctx, "Songs merged successfully", "survivor_id", survivor.ID)
+	return survivor, nil
+}
+
+// normalizeTitle приводит название к виду для сравнения: без уточнения версии, регистра и лишних символов
+func normalizeTitle(title string) string {
+	title = versionSuffix.ReplaceAllString(strings.ToLower(title), "")
+	title = strings.ReplaceAll(title, "ё", "е")
+	return strings.Join(strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// lyricSimilarity возвращает косинусную близость частот слов двух текстов от 0 до 1
+func lyricSimilarity(a, b string) float64 {
+	countsA, countsB := wordCounts(a), wordCounts(b)
+	if len(countsA) == 0 && len(countsB) == 0 {
+		return 1
+	}
+
+	dot, normA, normB := 0.0, 0.0, 0.0
+	for word, count := range countsA {
+		dot += float64(count * countsB[word])
+		normA += float64(count * count)
+	}
+	for _, count := range countsB {
+		normB += float64(count * count)
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
+
+// wordCounts считает вхождения слов в тексте
+func wordCounts(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, word := range tokenizeText(text) {
+		counts[word]++
+	}
+	return counts
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"song-libary/models"
+	"song-libary/repository"
+	"testing"
+)
+
+// fakeDuplicateRepo запоминает поля слияния и сохранённых кандидатов и возвращает заданную ошибку
+type fakeDuplicateRepo struct {
+	repository.DuplicateRepository
+	err        error
+	fields     []string
+	candidates []models.DuplicateCandidate
+	status     string
+}
+
+func (r *fakeDuplicateRepo) SaveDuplicateCandidates(_ context.Context, candidates []models.DuplicateCandidate) (int, error) {
+	r.candidates = candidates
+	return len(candidates), r.err
+}
+
+func (r *fakeDuplicateRepo) GetDuplicateCandidates(_ context.Context, status string, _, _ int) ([]models.DuplicateCandidate, error) {
+	r.status = status
+	return nil, r.err
+}
+
+func (r *fakeDuplicateRepo) DismissDuplicateCandidate(context.Context, int) error {
+	return r.err
+}
+
+// fakeSongList отдаёт песни порциями по возрастанию id, как ListSongs
+type fakeSongList struct {
+	repository.SongRepository
+	songs []*models.Song
+}
+
+func (r *fakeSongList) ListSongs(_ context.Context, afterID string, limit int) ([]*models.Song, error) {
+	var page []*models.Song
+	for _, song := range r.songs {
+		if song.ID > afterID && len(page) < limit {
+			page = append(page, song)
+		}
+	}
+	return page, nil
+}
+
+func (r *fakeDuplicateRepo) MergeSongs(_ context.Context, survivorID, _ string, fields []string) (*models.Song, error) {
+	r.fields = fields
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &models.Song{ID: survivorID, GroupName: "Embers", SongName: "Fire", Text: "Fire burning"}, nil
+}
+
+func TestScanDuplicates(t *testing.T) {
+	lyrics := "Cause I want it now, I want it now, give me your heart and your soul"
+	songs := &fakeSongList{songs: []*models.Song{
+		{ID: "1", GroupName: "Muse", SongName: "Hysteria", Text: lyrics},
+		{ID: "2", GroupName: "Muse", SongName: "Time Is Running Out", Text: lyrics},
+		{ID: "3", GroupName: "MUSE", SongName: "Hysteria (Live)", Text: lyrics + " tonight"},
+		{ID: "4", GroupName: "Muse", SongName: "Hysteria - Demo", Text: "An entirely different demo text about nothing"},
+		{ID: "5", GroupName: "Cover Band", SongName: "Hysteria", Text: lyrics},
+	}}
+	repo := &fakeDuplicateRepo{}
+	s := NewDuplicateService(repo, songs, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	added, err := s.ScanDuplicates(context.Background())
+	if err != nil {
+		t.Fatalf("ScanDuplicates() error = %v", err)
+	}
+	// Пара найдена только среди песен той же группы с тем же названием и похожим текстом
+	if added != 1 || len(repo.candidates) != 1 {
+		t.Fatalf("candidates = %+v (added %d), want one pair", repo.candidates, added)
+	}
+	got := repo.candidates[0]
+	if got.SongID != "1" || got.DuplicateID != "3" || got.Similarity < DefaultMinLyricSimilarity || got.Similarity >= 1 {
+		t.Errorf("candidate = %+v, want songs 1 and 3 with similarity in [%v, 1)", got, DefaultMinLyricSimilarity)
+	}
+}
+
+func TestGetCandidatesStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   string
+		err    error
+	}{
+		{status: "", want: models.DuplicateStatusPending},
+		{status: models.DuplicateStatusPending, want: models.DuplicateStatusPending},
+		{status: models.DuplicateStatusDismissed, want: models.DuplicateStatusDismissed},
+		{status: "merged", err: ErrInvalidDuplicateStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			repo := &fakeDuplicateRepo{}
+			s := NewDuplicateService(repo, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			_, err := s.GetCandidates(context.Background(), tt.status, 10, 0)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("GetCandidates(%q) error = %v, want %v", tt.status, err, tt.err)
+			}
+			if repo.status != tt.want {
+				t.Errorf("repository status = %q, want %q", repo.status, tt.want)
+			}
+		})
+	}
+}
+
+func TestDismissCandidate(t *testing.T) {
+	dbErr := errors.New("connection reset")
+	tests := []struct {
+		name    string
+		repoErr error
+		want    error
+	}{
+		{name: "dismissed"},
+		{name: "unknown candidate", repoErr: sql.ErrNoRows, want: ErrDuplicateCandidateNotFound},
+		{name: "database error", repoErr: dbErr, want: dbErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewDuplicateService(&fakeDuplicateRepo{err: tt.repoErr}, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if err := s.DismissCandidate(context.Background(), 7); !errors.Is(err, tt.want) {
+				t.Errorf("DismissCandidate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Hysteria", want: "hysteria"},
+		{title: "Hysteria (Live)", want: "hysteria"},
+		{title: "Hysteria [Remastered 2011]", want: "hysteria"},
+		{title: "Hysteria - Live at Wembley", want: "hysteria"},
+		{title: "Don't Stop Me Now!", want: "don t stop me now"},
+		{title: "  Ёлка  ", want: "елка"},
+		{title: "Song-2", want: "song 2"},
+	}
+	for _, tt := range tests {
+		if got := normalizeTitle(tt.title); got != tt.want {
+			t.Errorf("normalizeTitle(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestLyricSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{name: "identical", a: "Fire burning bright", b: "Fire burning bright", want: 1},
+		{name: "case and punctuation ignored", a: "Fire, burning bright!", b: "fire burning BRIGHT", want: 1},
+		{name: "disjoint", a: "Fire burning", b: "Ocean waves", want: 0},
+		{name: "partial overlap", a: "fire burning", b: "fire water", want: 0.5},
+		{name: "both empty", want: 1},
+		{name: "one empty", a: "Fire burning", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lyricSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("lyricSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeSongs(t *testing.T) {
+	dbErr := errors.New("connection reset")
+
+	tests := []struct {
+		name       string
+		req        models.MergeSongsRequest
+		repoErr    error
+		want       error
+		wantFields []string
+	}{
+		{
+			name:       "fields deduplicated",
+			req:        models.MergeSongsRequest{SurvivorID: fireID, DuplicateID: flameID, Fields: []string{"text", "link", "text"}},
+			wantFields: []string{"text", "link"},
+		},
+		{name: "no fields", req: models.MergeSongsRequest{SurvivorID: fireID, DuplicateID: flameID}, wantFields: []string{}},
+		{name: "same song", req: models.MergeSongsRequest{SurvivorID: fireID, DuplicateID: fireID}, want: ErrInvalidMerge},
+		{name: "invalid id", req: models.MergeSongsRequest{SurvivorID: "fire", DuplicateID: flameID}, want: ErrInvalidMerge},
+		{
+			name: "unknown field",
+			req:  models.MergeSongsRequest{SurvivorID: fireID, DuplicateID: flameID, Fields: []string{"text", "lyrics"}},
+			want: ErrInvalidMergeField,
+		},
+		{name: "song missing", req: models.MergeSongsRequest{SurvivorID: fireID, DuplicateID: flameID}, repoErr: sql.ErrNoRows, want: ErrSongNotFound},
+		{
+			name:    "isrc taken",
+			req:     models.MergeSongsRequest{SurvivorID: fireID, DuplicateID: flameID, Fields: []string{"isrc"}},
+			repoErr: repository.ErrDuplicateISRC,
+			want:    ErrDuplicateISRC,
+		},
+		{name: "database failure passed through", req: models.MergeSongsRequest{SurvivorID: fireID, DuplicateID: flameID}, repoErr: dbErr, want: dbErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeDuplicateRepo{err: tt.repoErr}
+			index := newTestIndex()
+			s := NewDuplicateService(repo, nil, index, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			_, err := s.MergeSongs(context.Background(), tt.req)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("MergeSongs() error = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				return
+			}
+			if !slices.Equal(repo.fields, tt.wantFields) {
+				t.Errorf("fields passed to repository = %q, want %q", repo.fields, tt.wantFields)
+			}
+			if _, ok := index.Similar(tt.req.DuplicateID, SimilarityOptions{Limit: 1}, nil); ok {
+				t.Error("merged duplicate still in similarity index")
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"song-libary/models"
+)
+
+// Kind — категория ошибки; по ней обработчики выбирают HTTP-статус
+type Kind int
+
+const (
+	KindInternal    Kind = iota // Непредвиденная ошибка
+	KindValidation              // Запрос не прошёл проверку
+	KindNotFound                // Объект не найден
+	KindConflict                // Запрос противоречит текущему состоянию, например занятый ISRC
+	KindUnavailable             // База данных или сервис временно недоступны
+	KindTimeout                 // Истёк дедлайн запроса
+)
+
+// Error — ошибка из каталога ошибок сервиса. Сравнивается по коду, поэтому копия с полями
+// или причиной остаётся равной исходной ошибке для errors.Is
+type Error struct {
+	Kind    Kind
+	Code    string              // Машинно-читаемый код, например song_not_found
+	Message string              // Описание для клиента
+	Fields  []models.FieldError // Ошибки отдельных полей запроса
+	Err     error               // Причина; клиенту не показывается
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Конструкторы ошибок каталога
+func newError(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func NotFound(code, message string) *Error    { return newError(KindNotFound, code, message) }
+func Conflict(code, message string) *Error    { return newError(KindConflict, code, message) }
+func Invalid(code, message string) *Error     { return newError(KindValidation, code, message) }
+func Unavailable(code, message string) *Error { return newError(KindUnavailable, code, message) }
+
+var (
+	ErrValidation  = Invalid("validation_failed", "request validation failed")
+	ErrUnavailable = Unavailable("database_unavailable", "database is unavailable")
+	ErrTimeout     = newError(KindTimeout, "timeout", "request deadline exceeded")
+	ErrInternal    = newError(KindInternal, "internal_error", "internal server error")
+)
+
+// ValidationError возвращает ошибку проверки запроса со всеми ошибками полей fields
+func ValidationError(fields ...models.FieldError) *Error {
+	e := *ErrValidation
+	e.Fields = fields
+	return &e
+}
+
+// withField относит ошибку проверки err к полю запроса field
+func withField(err error, field string) error {
+	var e *Error
+	if !errors.As(err, &e) || e.Kind != KindValidation {
+		return err
+	}
+	copied := *e
+	copied.Message = err.Error()
+	copied.Fields = []models.FieldError{{Field: field, Code: e.Code, Message: err.Error()}}
+	return &copied
+}
+
+// AsError возвращает ошибку каталога для err. Ошибки вне каталога относятся к инфраструктуре:
+// истёкший дедлайн ctx — KindTimeout, отменённый запрос и потерянное соединение с базой
+// данных — KindUnavailable, остальное — KindInternal. Драйвер PostgreSQL возвращает
+// собственную ошибку отмены, поэтому причина определяется и по ctx
+func AsError(ctx context.Context, err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(ctx.Err(), context.DeadlineExceeded):
+		e = ErrTimeout
+	case errors.Is(err, context.Canceled), ctx.Err() != nil,
+		errors.Is(err, sql.ErrConnDone), errors.Is(err, driver.ErrBadConn),
+		errors.As(err, &netErr):
+		e = ErrUnavailable
+	default:
+		e = ErrInternal
+	}
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
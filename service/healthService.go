@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"song-libary/models"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultHealthCheckTimeout ограничивает время одной проверки готовности
+const DefaultHealthCheckTimeout = 2 * time.Second
+
+var errShuttingDown = Unavailable("shutting_down", "server is shutting down")
+
+// HealthCheck проверяет доступность одной зависимости сервиса
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthService отвечает на проверки живости и готовности сервиса.
+// Готовность требует успешного прохождения всех проверок и перестаёт выполняться
+// с началом остановки сервера, чтобы балансировщик перестал направлять новые запросы
+type HealthService struct {
+	Checks  []HealthCheck
+	Timeout time.Duration
+	Logger  *slog.Logger
+
+	shuttingDown atomic.Bool
+}
+
+func NewHealthService(logger *slog.Logger, checks ...HealthCheck) *HealthService {
+	return &HealthService{Checks: checks, Timeout: DefaultHealthCheckTimeout, Logger: logger}
+}
+
+// SetShuttingDown отмечает начало остановки сервера
+func (s *HealthService) SetShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+// Liveness сообщает, что процесс работает и обслуживает запросы
+func (s *HealthService) Liveness() models.HealthResponse {
+	return models.HealthResponse{Status: models.HealthStatusOK}
+}
+
+// Readiness параллельно выполняет все проверки зависимостей и возвращает результат по каждой
+func (s *HealthService) Readiness(ctx context.Context) models.HealthResponse {
+	if s.shuttingDown.Load() {
+		return models.HealthResponse{
+			Status: models.HealthStatusFail,
+			Checks: []models.HealthCheck{{Name: "shutdown", Status: models.HealthStatusFail, Error: errShuttingDown.Error()}},
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	response := models.HealthResponse{
+		Status: models.HealthStatusOK,
+		Checks: make([]models.HealthCheck, len(s.Checks)),
+	}
+	var wg sync.WaitGroup
+	for i, check := range s.Checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response.Checks[i] = runHealthCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	for _, check := range response.Checks {
+		if check.Status != models.HealthStatusOK {
+			s.Logger.ErrorContext(ctx, "Readiness check failed", "check", check.Name, "error", check.Error)
+			response.Status = models.HealthStatusFail
+		}
+	}
+	return response
+}
+
+// runHealthCheck выполняет проверку и замеряет её время
+func runHealthCheck(ctx context.Context, check HealthCheck) models.HealthCheck {
+	started := time.Now()
+	err := check.Check(ctx)
+	result := models.HealthCheck{
+		Name:      check.Name,
+		Status:    models.HealthStatusOK,
+		LatencyMs: float64(time.Since(started).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = models.HealthStatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
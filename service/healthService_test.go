@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"song-libary/models"
+	"testing"
+	"time"
+)
+
+func passingCheck(name string) HealthCheck {
+	return HealthCheck{Name: name, Check: func(context.Context) error { return nil }}
+}
+
+func TestReadiness(t *testing.T) {
+	failing := HealthCheck{Name: "migrations", Check: func(context.Context) error { return errors.New("2 pending migrations") }}
+	hanging := HealthCheck{Name: "database", Check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	tests := []struct {
+		name         string
+		checks       []HealthCheck
+		shuttingDown bool
+		want         string
+		wantChecks   map[string]string // Состояние каждой проверки по имени
+	}{
+		{
+			name:       "all pass",
+			checks:     []HealthCheck{passingCheck("database"), passingCheck("migrations")},
+			want:       models.HealthStatusOK,
+			wantChecks: map[string]string{"database": models.HealthStatusOK, "migrations": models.HealthStatusOK},
+		},
+		{
+			name:       "one fails",
+			checks:     []HealthCheck{passingCheck("database"), failing},
+			want:       models.HealthStatusFail,
+			wantChecks: map[string]string{"database": models.HealthStatusOK, "migrations": models.HealthStatusFail},
+		},
+		{
+			name:       "check hangs until timeout",
+			checks:     []HealthCheck{hanging, passingCheck("migrations")},
+			want:       models.HealthStatusFail,
+			wantChecks: map[string]string{"database": models.HealthStatusFail, "migrations": models.HealthStatusOK},
+		},
+		{
+			name:         "shutting down",
+			checks:       []HealthCheck{passingCheck("database")},
+			shuttingDown: true,
+			want:         models.HealthStatusFail,
+			wantChecks:   map[string]string{"shutdown": models.HealthStatusFail},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewHealthService(slog.New(slog.NewTextHandler(io.Discard, nil)), tt.checks...)
+			s.Timeout = 50 * time.Millisecond
+			if tt.shuttingDown {
+				s.SetShuttingDown()
+			}
+
+			started := time.Now()
+			response := s.Readiness(context.Background())
+			if elapsed := time.Since(started); elapsed > time.Second {
+				t.Errorf("Readiness() took %v, want it bounded by the timeout", elapsed)
+			}
+
+			if response.Status != tt.want {
+				t.Errorf("status = %q, want %q", response.Status, tt.want)
+			}
+			if len(response.Checks) != len(tt.wantChecks) {
+				t.Fatalf("checks = %+v, want %d", response.Checks, len(tt.wantChecks))
+			}
+			for _, check := range response.Checks {
+				if want, ok := tt.wantChecks[check.Name]; !ok || check.Status != want {
+					t.Errorf("check %q status = %q, want %q", check.Name, check.Status, want)
+				}
+				if check.Status == models.HealthStatusFail && check.Error == "" {
+					t.Errorf("failed check %q has no error", check.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestLiveness(t *testing.T) {
+	s := NewHealthService(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.SetShuttingDown()
+	if got := s.Liveness().Status; got != models.HealthStatusOK {
+		t.Errorf("Liveness() status = %q during shutdown, want %q", got, models.HealthStatusOK)
+	}
+}
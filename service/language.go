@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"song-libary/models"
+	"song-libary/tracing"
+)
+
+// resolveLanguage возвращает язык текста: заданный вручную override или определённый детектором
+func (s *SongService) resolveLanguage(text, override string) (models.LanguageInfo, error) {
+	if override != "" {
+		lang := normalizeLang(override)
+		if !langPattern.MatchString(lang) {
+			return models.LanguageInfo{}, ErrInvalidLang
+		}
+		return models.LanguageInfo{Language: lang, Confidence: 1, Manual: true}, nil
+	}
+
+	result := s.Detector.Detect(text)
+	return models.LanguageInfo{Language: result.Lang, Confidence: result.Confidence}, nil
+}
+
+// BackfillLanguages определяет язык для всех песен, у которых он ещё не сохранён.
+// Песни обрабатываются порциями по batchSize; возвращается число обновлённых песен
+func (s *SongService) BackfillLanguages(ctx context.Context, batchSize int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "SongService.BackfillLanguages")
+	defer tracing.End(span, &err)
+
+	s.Logger.InfoContext(ctx, "Backfilling song languages", "batch_size", batchSize)
+
+	updated, afterID := 0, ""
+	for {
+		songs, err := s.Repo.GetSongsWithoutLanguage(ctx, afterID, batchSize)
+		if err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to fetch songs for language backfill", "error", err)
+			return updated, err
+		}
+		if len(songs) == 0 {
+			break
+		}
+
+		for _, song := range songs {
+			afterID = song.ID
+
+			language, _ := s.resolveLanguage(song.Text, "")
+			if language.Language == "" {
+				s.Logger.DebugContext(ctx, "Language of song is undetermined", "id", song.ID)
+				continue
+			}
+			if err := s.Repo.UpdateSongLanguage(ctx, song.ID, language); err != nil {
+				return updated, err
+			}
+			updated++
+		}
+	}
+
+	s.Logger.InfoContext(ctx, "Language backfill finished", "updated", updated)
+	return updated, nil
+}
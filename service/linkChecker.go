@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"song-libary/models"
+	"song-libary/repository"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// defaultRetryAfter — пауза перед повторным обращением к хосту, ответившему 429 без Retry-After
+const defaultRetryAfter = time.Minute
+
+// ErrForbiddenAddress возвращается при попытке проверить ссылку, ведущую во внутреннюю сеть
+var ErrForbiddenAddress = errors.New("link points to a private or loopback address")
+
+// LinkCheckerConfig задаёт параметры фоновой проверки ссылок; значения берутся из config.LinkCheckerConfig
+type LinkCheckerConfig struct {
+	Interval           time.Duration // Пауза между проходами
+	RecheckAfter       time.Duration // Через сколько перепроверять уже проверенную ссылку
+	BatchSize          int           // Сколько ссылок проверять за один проход
+	RequestsPerSecond  float64       // Общий лимит запросов в секунду
+	PerHostConcurrency int           // Максимум одновременных запросов к одному хосту
+	Timeout            time.Duration // Таймаут одного запроса
+	BrokenAfter        int           // После скольких неудач подряд ссылка считается нерабочей
+	UserAgent          string
+}
+
+// LinkChecker периодически проверяет сохранённые ссылки HEAD/GET-запросами
+// и записывает HTTP-статус, время проверки и число неудач подряд.
+// Ответ 429 не считается неудачей: хост и его ссылки откладываются до истечения Retry-After,
+// а ссылки проверяются в одном из следующих проходов после этого времени
+type LinkChecker struct {
+	Repo   repository.LinkRepository
+	Client *http.Client
+	Now    func() time.Time
+	Config LinkCheckerConfig
+	Logger *slog.Logger
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+// hostState — ограничение одновременных запросов к хосту и время, до которого хост попросил
+// не обращаться к нему. Запись удаляется, когда к хосту нет запросов и пауза истекла
+type hostState struct {
+	slots   chan struct{}
+	users   int
+	retryAt time.Time
+}
+
+// NewLinkChecker создает проверку ссылок. client выполняет запросы (см. NewLinkCheckerClient),
+// now возвращает текущее время
+func NewLinkChecker(repo repository.LinkRepository, client *http.Client, now func() time.Time, config LinkCheckerConfig, logger *slog.Logger) *LinkChecker {
+	return &LinkChecker{
+		Repo:   repo,
+		Client: client,
+		Now:    now,
+		Config: config,
+		Logger: logger,
+		hosts:  make(map[string]*hostState),
+	}
+}
+
+// NewLinkCheckerClient создает HTTP-клиент, который не подключается к адресам loopback,
+// link-local, частных сетей RFC 1918 и unspecified: адрес проверяется после разрешения имени,
+// в том числе при переходе по редиректу
+func NewLinkCheckerClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: rejectInternalAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // Через прокси адрес назначения проверить нельзя
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// rejectInternalAddress запрещает соединения с адресами внутренней сети
+func rejectInternalAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+	}
+	return nil
+}
+
+// Run выполняет проходы проверки с интервалом Config.Interval, пока не отменён ctx
+func (c *LinkChecker) Run(ctx context.Context) {
+	c.Logger.InfoContext(ctx, "Link checker started", "interval", c.Config.Interval, "requests_per_second", c.Config.RequestsPerSecond)
+
+	ticker := time.NewTicker(c.Config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.CheckOnce(ctx); err != nil && ctx.Err() == nil {
+			c.Logger.ErrorContext(ctx, "Link check pass failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			c.Logger.InfoContext(ctx, "Link checker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckOnce проверяет одну порцию ссылок и возвращает число проверенных
+func (c *LinkChecker) CheckOnce(ctx context.Context) (int, error) {
+	links, err := c.Repo.GetLinksToCheck(ctx, c.Config.RecheckAfter, c.Config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(links) == 0 {
+		return 0, nil
+	}
+
+	limiter := newRequestLimiter(c.Config.RequestsPerSecond)
+	defer limiter.Stop()
+
+	var wg sync.WaitGroup
+	checked := 0
+	for _, link := range links {
+		if err := limiter.Wait(ctx); err != nil {
+			break
+		}
+
+		wg.Add(1)
+		checked++
+		go func(link *models.SongLink) {
+			defer wg.Done()
+			c.checkLink(ctx, link)
+		}(link)
+	}
+	wg.Wait()
+	c.evictHosts()
+
+	c.Logger.InfoContext(ctx, "Link check pass finished", "checked", checked)
+	return checked, ctx.Err()
+}
+
+// checkLink проверяет одну ссылку с учётом ограничения на число запросов к хосту
+func (c *LinkChecker) checkLink(ctx context.Context, link *models.SongLink) {
+	parsed, err := url.Parse(link.URL)
+	if err != nil {
+		c.Logger.ErrorContext(ctx, "Failed to parse stored link", "id", link.ID, "error", err)
+		return
+	}
+
+	host := c.acquireHost(parsed.Host)
+	defer c.releaseHost(parsed.Host)
+	select {
+	case host.slots <- struct{}{}:
+		defer func() { <-host.slots }()
+	case <-ctx.Done():
+		return
+	}
+	if retryAt, backingOff := c.hostRetryAt(host); backingOff {
+		c.Logger.DebugContext(ctx, "Host asked to retry later, deferring link", "id", link.ID, "host", parsed.Host)
+		c.deferLink(ctx, link, retryAt)
+		return
+	}
+
+	result := c.probe(ctx, link.URL)
+	if ctx.Err() != nil {
+		// Прерванная остановкой проверка не должна считаться неудачей
+		return
+	}
+	if result.StatusCode == http.StatusTooManyRequests {
+		retryAt := c.backOffHost(host, result.RetryAfter)
+		c.Logger.InfoContext(ctx, "Host rate limited link check", "id", link.ID, "host", parsed.Host, "retry_after", result.RetryAfter)
+		c.deferLink(ctx, link, retryAt)
+		return
+	}
+
+	ok := result.StatusCode > 0 && result.StatusCode < http.StatusBadRequest
+	if !ok {
+		c.Logger.InfoContext(ctx, "Link failed check", "id", link.ID, "status_code", result.StatusCode, "url", link.URL)
+	}
+	if err := c.Repo.SaveLinkCheck(ctx, link.ID, result.StatusCode, ok, c.Config.BrokenAfter); err != nil {
+		c.Logger.ErrorContext(ctx, "Failed to save check result for link", "id", link.ID, "error", err)
+	}
+}
+
+// probeResult — ответ на проверочный запрос
+type probeResult struct {
+	StatusCode int           // HTTP-статус, 0 при сетевой ошибке
+	RetryAfter time.Duration // Пауза из заголовка Retry-After ответа 429
+}
+
+// probe отправляет HEAD-запрос, а если сервер его не поддерживает — GET
+func (c *LinkChecker) probe(ctx context.Context, target string) probeResult {
+	result := c.request(ctx, http.MethodHead, target)
+	switch result.StatusCode {
+	case 0, http.StatusMethodNotAllowed, http.StatusNotImplemented, http.StatusForbidden:
+		return c.request(ctx, http.MethodGet, target)
+	}
+	return result
+}
+
+// request выполняет запрос и возвращает его результат
+func (c *LinkChecker) request(ctx context.Context, method, target string) probeResult {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return probeResult{}
+	}
+	req.Header.Set("User-Agent", c.Config.UserAgent)
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		c.Logger.DebugContext(ctx, "Link request failed", "method", method, "target", target, "error", err)
+		return probeResult{}
+	}
+	defer resp.Body.Close()
+
+	// Тело не нужно, но небольшое чтение позволяет переиспользовать соединение
+	_, _ = io.CopyN(io.Discard, resp.Body, 4096)
+
+	result := probeResult{StatusCode: resp.StatusCode}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		result.RetryAfter = c.retryAfter(resp.Header.Get("Retry-After"))
+	}
+	return result
+}
+
+// retryAfter разбирает заголовок Retry-After: число секунд или HTTP-дату
+func (c *LinkChecker) retryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(c.Now()), 0)
+	}
+	return defaultRetryAfter
+}
+
+// acquireHost возвращает состояние хоста и отмечает, что к нему выполняется запрос;
+// парный вызов releaseHost обязателен
+func (c *LinkChecker) acquireHost(host string) *hostState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, ok := c.hosts[host]
+	if !ok {
+		size := c.Config.PerHostConcurrency
+		if size <= 0 {
+			size = 1
+		}
+		state = &hostState{slots: make(chan struct{}, size)}
+		c.hosts[host] = state
+	}
+	state.users++
+	return state
+}
+
+// releaseHost снимает отметку acquireHost и удаляет запись хоста, если она больше не нужна
+func (c *LinkChecker) releaseHost(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if state, ok := c.hosts[host]; ok {
+		state.users--
+		if state.users == 0 && !state.retryAt.After(c.Now()) {
+			delete(c.hosts, host)
+		}
+	}
+}
+
+// evictHosts удаляет записи хостов, пауза которых истекла
+func (c *LinkChecker) evictHosts() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.Now()
+	for host, state := range c.hosts {
+		if state.users == 0 && !state.retryAt.After(now) {
+			delete(c.hosts, host)
+		}
+	}
+}
+
+// hostRetryAt возвращает время, до которого хост попросил не обращаться к нему,
+// и сообщает, наступило ли оно
+func (c *LinkChecker) hostRetryAt(state *hostState) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return state.retryAt, state.retryAt.After(c.Now())
+}
+
+// backOffHost откладывает запросы к хосту на retryAfter и возвращает время, до которого они отложены
+func (c *LinkChecker) backOffHost(state *hostState, retryAfter time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if at := c.Now().Add(retryAfter); at.After(state.retryAt) {
+		state.retryAt = at
+	}
+	return state.retryAt
+}
+
+// deferLink откладывает проверку ссылки до until, чтобы отложенные ссылки не занимали начало
+// следующих порций и не мешали проверять ссылки других хостов
+func (c *LinkChecker) deferLink(ctx context.Context, link *models.SongLink, until time.Time) {
+	if err := c.Repo.DeferLinkCheck(ctx, link.ID, until); err != nil {
+		c.Logger.ErrorContext(ctx, "Failed to defer link check", "id", link.ID, "error", err)
+	}
+}
+
+// requestLimiter равномерно распределяет запросы во времени
+type requestLimiter struct {
+	ticker *time.Ticker
+}
+
+func newRequestLimiter(perSecond float64) *requestLimiter {
+	if perSecond <= 0 {
+		return &requestLimiter{}
+	}
+	return &requestLimiter{ticker: time.NewTicker(time.Duration(float64(time.Second) / perSecond))}
+}
+
+// Wait блокируется до следующего разрешённого запроса или отмены ctx
+func (l *requestLimiter) Wait(ctx context.Context) error {
+	if l.ticker == nil {
+		return ctx.Err()
+	}
+	select {
+	case <-l.ticker.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *requestLimiter) Stop() {
+	if l.ticker != nil {
+		l.ticker.Stop()
+	}
+}
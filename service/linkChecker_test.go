@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"song-libary/models"
+	"song-libary/repository"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLinkRepo отдаёт заданные ссылки и запоминает результаты проверок
+type fakeLinkRepo struct {
+	repository.LinkRepository
+
+	links []*models.SongLink
+
+	mu       sync.Mutex
+	checks   map[int]linkCheck
+	deferred map[int]time.Time
+}
+
+type linkCheck struct {
+	statusCode int
+	ok         bool
+}
+
+func (r *fakeLinkRepo) GetLinksToCheck(context.Context, time.Duration, int) ([]*models.SongLink, error) {
+	return r.links, nil
+}
+
+func (r *fakeLinkRepo) SaveLinkCheck(_ context.Context, id, statusCode int, ok bool, _ int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.checks == nil {
+		r.checks = make(map[int]linkCheck)
+	}
+	r.checks[id] = linkCheck{statusCode, ok}
+	return nil
+}
+
+func (r *fakeLinkRepo) DeferLinkCheck(_ context.Context, id int, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.deferred == nil {
+		r.deferred = make(map[int]time.Time)
+	}
+	r.deferred[id] = until
+	return nil
+}
+
+// queueLinkRepo выбирает порции так же, как GetLinksToCheck: непроверенные ссылки первыми,
+// без отложенных до наступления next_check_at, не больше limit
+type queueLinkRepo struct {
+	fakeLinkRepo
+	now     func() time.Time
+	checked map[int]time.Time
+}
+
+func (r *queueLinkRepo) GetLinksToCheck(_ context.Context, _ time.Duration, limit int) ([]*models.SongLink, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var batch []*models.SongLink
+	for _, link := range r.links {
+		if _, done := r.checked[link.ID]; done {
+			continue
+		}
+		if until, ok := r.deferred[link.ID]; ok && until.After(r.now()) {
+			continue
+		}
+		if len(batch) < limit {
+			batch = append(batch, link)
+		}
+	}
+	return batch, nil
+}
+
+func (r *queueLinkRepo) SaveLinkCheck(ctx context.Context, id, statusCode int, ok bool, brokenAfter int) error {
+	r.mu.Lock()
+	r.checked[id] = r.now()
+	delete(r.deferred, id)
+	r.mu.Unlock()
+	return r.fakeLinkRepo.SaveLinkCheck(ctx, id, statusCode, ok, brokenAfter)
+}
+
+func newTestLinkChecker(repo repository.LinkRepository, client *http.Client, now func() time.Time) *LinkChecker {
+	config := LinkCheckerConfig{
+		Interval:           time.Hour,
+		RecheckAfter:       24 * time.Hour,
+		BatchSize:          100,
+		PerHostConcurrency: 1,
+		Timeout:            10 * time.Second,
+		BrokenAfter:        3,
+		UserAgent:          "song-library-link-checker/test",
+	}
+	return NewLinkChecker(repo, client, now, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestLinkCheckerCheckOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/get-only":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		path  string
+		want  linkCheck
+		saved bool
+	}{
+		{name: "ok", path: "/ok", want: linkCheck{http.StatusOK, true}, saved: true},
+		{name: "head not allowed falls back to get", path: "/get-only", want: linkCheck{http.StatusOK, true}, saved: true},
+		{name: "redirect followed", path: "/redirect", want: linkCheck{http.StatusOK, true}, saved: true},
+		{name: "not found", path: "/missing", want: linkCheck{http.StatusNotFound, false}, saved: true},
+		{name: "server error", path: "/error", want: linkCheck{http.StatusInternalServerError, false}, saved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeLinkRepo{links: []*models.SongLink{{ID: 1, URL: server.URL + tt.path}}}
+			checker := newTestLinkChecker(repo, server.Client(), time.Now)
+
+			if _, err := checker.CheckOnce(context.Background()); err != nil {
+				t.Fatalf("CheckOnce() error = %v", err)
+			}
+			got, saved := repo.checks[1]
+			if saved != tt.saved || got != tt.want {
+				t.Errorf("saved check = %+v (saved %v), want %+v (saved %v)", got, saved, tt.want, tt.saved)
+			}
+		})
+	}
+}
+
+func TestLinkCheckerBacksOffRateLimitedHost(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	limited := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if limited {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)
+	repo := &fakeLinkRepo{links: []*models.SongLink{
+		{ID: 1, URL: server.URL + "/a"},
+		{ID: 2, URL: server.URL + "/b"},
+	}}
+	checker := newTestLinkChecker(repo, server.Client(), func() time.Time { return now })
+
+	if _, err := checker.CheckOnce(context.Background()); err != nil {
+		t.Fatalf("CheckOnce() error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests to rate limited host = %d, want 1", requests)
+	}
+	if len(repo.checks) != 0 {
+		t.Errorf("rate limited links saved as checked: %+v", repo.checks)
+	}
+	wantDeferred := now.Add(2 * time.Minute)
+	if len(repo.deferred) != 2 || !repo.deferred[1].Equal(wantDeferred) || !repo.deferred[2].Equal(wantDeferred) {
+		t.Errorf("deferred links = %+v, want both until %v", repo.deferred, wantDeferred)
+	}
+
+	mu.Lock()
+	limited = false
+	mu.Unlock()
+	now = now.Add(2 * time.Minute)
+
+	if _, err := checker.CheckOnce(context.Background()); err != nil {
+		t.Fatalf("CheckOnce() error = %v", err)
+	}
+	if len(repo.checks) != 2 {
+		t.Errorf("links checked after Retry-After = %d, want 2", len(repo.checks))
+	}
+	if len(checker.hosts) != 0 {
+		t.Errorf("host entries left after pass: %d", len(checker.hosts))
+	}
+}
+
+func TestLinkCheckerRateLimitedHostDoesNotBlockOthers(t *testing.T) {
+	var mu sync.Mutex
+	limitedRequests := 0
+	limitedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		limitedRequests++
+		mu.Unlock()
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer limitedServer.Close()
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer okServer.Close()
+
+	now := time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	// Ссылки ограниченного хоста стоят в начале очереди и целиком заполняют порцию
+	repo := &queueLinkRepo{
+		fakeLinkRepo: fakeLinkRepo{links: []*models.SongLink{
+			{ID: 1, URL: limitedServer.URL + "/a"},
+			{ID: 2, URL: limitedServer.URL + "/b"},
+			{ID: 3, URL: okServer.URL + "/c"},
+			{ID: 4, URL: okServer.URL + "/d"},
+		}},
+		now:     clock,
+		checked: make(map[int]time.Time),
+	}
+	checker := newTestLinkChecker(repo, limitedServer.Client(), clock)
+	checker.Config.BatchSize = 2
+
+	// Хост отвечает 429 дольше, чем длятся все проходы
+	for pass := range 5 {
+		if _, err := checker.CheckOnce(context.Background()); err != nil {
+			t.Fatalf("pass %d: CheckOnce() error = %v", pass, err)
+		}
+		now = now.Add(10 * time.Minute)
+	}
+
+	for _, id := range []int{3, 4} {
+		if got, ok := repo.checks[id]; !ok || !got.ok {
+			t.Errorf("link %d of the other host: check = %+v (saved %v), want ok", id, got, ok)
+		}
+	}
+	for _, id := range []int{1, 2} {
+		if _, ok := repo.checks[id]; ok {
+			t.Errorf("rate limited link %d saved as checked", id)
+		}
+		if until := repo.deferred[id]; until.IsZero() {
+			t.Errorf("rate limited link %d not deferred", id)
+		}
+	}
+	if limitedRequests != 1 {
+		t.Errorf("requests to rate limited host = %d, want 1", limitedRequests)
+	}
+}
+
+func TestLinkCheckerRetryAfter(t *testing.T) {
+	now := time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)
+	checker := newTestLinkChecker(nil, nil, func() time.Time { return now })
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "30", want: 30 * time.Second},
+		{value: now.Add(time.Hour).Format(http.TimeFormat), want: time.Hour},
+		{value: now.Add(-time.Hour).Format(http.TimeFormat), want: 0},
+		{value: "", want: defaultRetryAfter},
+		{value: "soon", want: defaultRetryAfter},
+	}
+	for _, tt := range tests {
+		if got := checker.retryAfter(tt.value); got != tt.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRejectInternalAddress(t *testing.T) {
+	tests := []struct {
+		ip      string
+		allowed bool
+	}{
+		{ip: "93.184.216.34", allowed: true},
+		{ip: "2606:4700::1111", allowed: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "10.1.2.3"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "fc00::1"},
+		{ip: "0.0.0.0"},
+		{ip: "::ffff:127.0.0.1"},
+	}
+	for _, tt := range tests {
+		err := rejectInternalAddress("tcp", net.JoinHostPort(tt.ip, "443"), nil)
+		if allowed := err == nil; allowed != tt.allowed {
+			t.Errorf("rejectInternalAddress(%s) error = %v, want allowed %v", tt.ip, err, tt.allowed)
+		}
+	}
+}
+
+func TestLinkCheckerClientRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached loopback server")
+	}))
+	defer server.Close()
+
+	_, err := NewLinkCheckerClient(time.Second).Get(server.URL)
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("Get(%s) error = %v, want ErrForbiddenAddress", server.URL, err)
+	}
+}
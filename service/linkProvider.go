@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"song-libary/models"
+	"strings"
+)
+
+var ErrInvalidLink = Invalid("invalid_link", "invalid link")
+
+var (
+	youTubeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	spotifyIDPattern = regexp.MustCompile(`^[A-Za-z0-9]{22}$`)
+	appleIDPattern   = regexp.MustCompile(`^[0-9]+$`)
+	regionPattern    = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// detectSongLink определяет ссылку, указанную в данных песни; пустая ссылка — nil
+func detectSongLink(rawURL string) (*models.SongLink, error) {
+	if strings.TrimSpace(rawURL) == "" {
+		return nil, nil
+	}
+	return detectLink(rawURL)
+}
+
+// detectLink проверяет ссылку, определяет провайдера, идентификатор трека и регион
+func detectLink(rawURL string) (*models.SongLink, error) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	// Spotify URI (spotify:track:ID) не является http-ссылкой, но однозначно задаёт трек
+	if rest, ok := strings.CutPrefix(rawURL, "spotify:track:"); ok {
+		if !spotifyIDPattern.MatchString(rest) {
+			return nil, fmt.Errorf("%w: malformed Spotify track ID", ErrInvalidLink)
+		}
+		return &models.SongLink{Provider: models.LinkProviderSpotify, URL: rawURL, ExternalID: rest}, nil
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return nil, fmt.Errorf("%w: expected an absolute http(s) URL", ErrInvalidLink)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	host = strings.TrimPrefix(host, "m.")
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+
+	link := &models.SongLink{Provider: models.LinkProviderOther, URL: rawURL}
+	switch {
+	case host == "youtu.be" || host == "youtube.com" || host == "music.youtube.com" || host == "youtube-nocookie.com":
+		link.Provider = models.LinkProviderYouTube
+		link.ExternalID, err = youTubeID(host, parsed, segments)
+	case host == "open.spotify.com":
+		link.Provider = models.LinkProviderSpotify
+		link.ExternalID, err = spotifyID(segments)
+	case host == "music.apple.com":
+		link.Provider = models.LinkProviderAppleMusic
+		link.ExternalID, link.Region, err = appleMusicID(parsed, segments)
+	case strings.HasSuffix(host, ".bandcamp.com"):
+		link.Provider = models.LinkProviderBandcamp
+		link.ExternalID, err = bandcampID(host, segments)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// youTubeID извлекает идентификатор видео из любых форм ссылок YouTube:
+// youtu.be/ID, /watch?v=ID, /embed/ID, /v/ID, /shorts/ID, /live/ID
+func youTubeID(host string, parsed *url.URL, segments []string) (string, error) {
+	var id string
+	switch {
+	case host == "youtu.be":
+		id = segments[0]
+	case segments[0] == "watch":
+		id = parsed.Query().Get("v")
+	case len(segments) >= 2 && (segments[0] == "embed" || segments[0] == "v" || segments[0] == "shorts" || segments[0] == "live"):
+		id = segments[1]
+	}
+
+	if !youTubeIDPattern.MatchString(id) {
+		return "", fmt.Errorf("%w: no video ID in YouTube URL", ErrInvalidLink)
+	}
+	return id, nil
+}
+
+// spotifyID извлекает идентификатор трека из open.spotify.com/[intl-xx/]track/ID
+func spotifyID(segments []string) (string, error) {
+	if len(segments) > 0 && strings.HasPrefix(segments[0], "intl-") {
+		segments = segments[1:]
+	}
+	if len(segments) < 2 || segments[0] != "track" || !spotifyIDPattern.MatchString(segments[1]) {
+		return "", fmt.Errorf("%w: expected open.spotify.com/track/<id>", ErrInvalidLink)
+	}
+	return segments[1], nil
+}
+
+// appleMusicID извлекает идентификатор трека и витрину (регион) из ссылок вида
+// music.apple.com/us/album/name/123?i=456 и music.apple.com/us/song/name/456
+func appleMusicID(parsed *url.URL, segments []string) (string, string, error) {
+	if len(segments) < 3 || len(segments[0]) != 2 {
+		return "", "", fmt.Errorf("%w: expected music.apple.com/<country>/song|album/...", ErrInvalidLink)
+	}
+	region := strings.ToUpper(segments[0])
+
+	var id string
+	switch segments[1] {
+	case "song":
+		id = segments[len(segments)-1]
+	case "album":
+		id = parsed.Query().Get("i")
+	}
+
+	if !appleIDPattern.MatchString(id) {
+		return "", "", fmt.Errorf("%w: no track ID in Apple Music URL", ErrInvalidLink)
+	}
+	return id, region, nil
+}
+
+// bandcampID возвращает идентификатор вида artist/track-slug для artist.bandcamp.com/track/slug
+func bandcampID(host string, segments []string) (string, error) {
+	if len(segments) < 2 || (segments[0] != "track" && segments[0] != "album") || segments[1] == "" {
+		return "", fmt.Errorf("%w: expected <artist>.bandcamp.com/track/<slug>", ErrInvalidLink)
+	}
+	return strings.TrimSuffix(host, ".bandcamp.com") + "/" + segments[1], nil
+}
+
+// normalizeRegion проверяет код региона ISO 3166-1 alpha-2
+func normalizeRegion(region string) (string, error) {
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if region != "" && !regionPattern.MatchString(region) {
+		return "", fmt.Errorf("%w: region must be a two-letter country code", ErrInvalidLink)
+	}
+	return region, nil
+}
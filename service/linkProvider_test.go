@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"song-libary/models"
+	"testing"
+)
+
+func TestDetectSongLink(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    *models.SongLink
+		wantErr bool
+	}{
+		{name: "empty", url: "  "},
+		{
+			name: "youtube watch",
+			url:  "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+			want: &models.SongLink{Provider: models.LinkProviderYouTube, URL: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", ExternalID: "dQw4w9WgXcQ"},
+		},
+		{
+			name: "youtube short link",
+			url:  "https://youtu.be/dQw4w9WgXcQ",
+			want: &models.SongLink{Provider: models.LinkProviderYouTube, URL: "https://youtu.be/dQw4w9WgXcQ", ExternalID: "dQw4w9WgXcQ"},
+		},
+		{
+			name: "spotify uri",
+			url:  "spotify:track:4uLU6hMCjMI75M1A2tKUQC",
+			want: &models.SongLink{Provider: models.LinkProviderSpotify, URL: "spotify:track:4uLU6hMCjMI75M1A2tKUQC", ExternalID: "4uLU6hMCjMI75M1A2tKUQC"},
+		},
+		{
+			name: "spotify localized",
+			url:  "https://open.spotify.com/intl-de/track/4uLU6hMCjMI75M1A2tKUQC",
+			want: &models.SongLink{Provider: models.LinkProviderSpotify, URL: "https://open.spotify.com/intl-de/track/4uLU6hMCjMI75M1A2tKUQC", ExternalID: "4uLU6hMCjMI75M1A2tKUQC"},
+		},
+		{
+			name: "apple music album track",
+			url:  "https://music.apple.com/us/album/name/123?i=456",
+			want: &models.SongLink{Provider: models.LinkProviderAppleMusic, URL: "https://music.apple.com/us/album/name/123?i=456", ExternalID: "456", Region: "US"},
+		},
+		{
+			name: "bandcamp",
+			url:  "https://artist.bandcamp.com/track/song",
+			want: &models.SongLink{Provider: models.LinkProviderBandcamp, URL: "https://artist.bandcamp.com/track/song", ExternalID: "artist/song"},
+		},
+		{
+			name: "other provider",
+			url:  "https://example.com/song",
+			want: &models.SongLink{Provider: models.LinkProviderOther, URL: "https://example.com/song"},
+		},
+		{name: "youtube without video", url: "https://www.youtube.com/channel/abc", wantErr: true},
+		{name: "malformed spotify uri", url: "spotify:track:short", wantErr: true},
+		{name: "not http", url: "ftp://example.com/song.mp3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detectSongLink(tt.url)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidLink) {
+					t.Fatalf("detectSongLink(%q) error = %v, want ErrInvalidLink", tt.url, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("detectSongLink(%q) error = %v", tt.url, err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("detectSongLink(%q) = %+v, want %+v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"song-libary/models"
+	"song-libary/repository"
+)
+
+var (
+	ErrLinkNotFound  = NotFound("link_not_found", "link not found")
+	ErrDuplicateLink = Conflict("duplicate_link", "song already has this link")
+)
+
+type LinkService struct {
+	Repo   repository.LinkRepository
+	Logger *slog.Logger
+}
+
+func NewLinkService(repo repository.LinkRepository, logger *slog.Logger) *LinkService {
+	return &LinkService{Repo: repo, Logger: logger}
+}
+
+// AddLink проверяет ссылку, определяет провайдера и сохраняет её
+func (s *LinkService) AddLink(ctx context.Context, req models.AddSongLinkRequest) (*models.SongLink, error) {
+	s.Logger.InfoContext(ctx, "Adding link", "song", req.Song, "group", req.Group)
+
+	link, err := detectLink(req.URL)
+	if err != nil {
+		return nil, withField(err, "url")
+	}
+
+	// Явно указанный регион важнее определённого по ссылке
+	region, err := normalizeRegion(req.Region)
+	if err != nil {
+		return nil, withField(err, "region")
+	}
+	if region != "" {
+		link.Region = region
+	}
+
+	if err := s.Repo.AddSongLink(ctx, req.Song, req.Group, link); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrSongNotFound
+		case errors.Is(err, repository.ErrDuplicateLink):
+			return nil, ErrDuplicateLink
+		}
+		s.Logger.ErrorContext(ctx, "Failed to add link", "error", err)
+		return nil, err
+	}
+
+	s.Logger.InfoContext(ctx, "Link added successfully", "provider", link.Provider, "external_id", link.ExternalID)
+	return link, nil
+}
+
+// DeleteLink удаляет ссылку по идентификатору
+func (s *LinkService) DeleteLink(ctx context.Context, id int) error {
+	s.Logger.InfoContext(ctx, "Deleting link", "id", id)
+
+	if err := s.Repo.DeleteSongLink(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrLinkNotFound
+		}
+		s.Logger.ErrorContext(ctx, "Failed to delete link", "error", err)
+		return err
+	}
+	return nil
+}
+
+// GetLinks возвращает ссылки на песню, сгруппированные по провайдеру
+func (s *LinkService) GetLinks(ctx context.Context, songName, group string) (map[string][]*models.SongLink, error) {
+	s.Logger.InfoContext(ctx, "Fetching links", "song", songName, "group", group)
+
+	links, err := s.Repo.GetSongLinks(ctx, songName, group)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSongNotFound
+		}
+		s.Logger.ErrorContext(ctx, "Failed to fetch links", "error", err)
+		return nil, err
+	}
+
+	grouped := make(map[string][]*models.SongLink)
+	for _, link := range links {
+		grouped[link.Provider] = append(grouped[link.Provider], link)
+	}
+	return grouped, nil
+}
+
+// GetBrokenLinks возвращает отчёт о нерабочих ссылках
+func (s *LinkService) GetBrokenLinks(ctx context.Context) ([]*models.BrokenLink, error) {
+	s.Logger.InfoContext(ctx, "Fetching broken links report")
+	return s.Repo.GetBrokenLinks(ctx)
+}
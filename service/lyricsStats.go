@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go.opentelemetry.io/otel/attribute"
+	"regexp"
+	"song-libary/models"
+	"song-libary/tracing"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidSongID = Invalid("invalid_song_id", "song id must be a UUID")
+	ErrInvalidTop    = Invalid("invalid_top", "top must be between 1 and 100")
+)
+
+// Количество самых частых слов в статистике
+const (
+	DefaultTopWords = 10
+	MaxTopWords     = 100
+)
+
+// uuidPattern проверяет идентификатор песни до обращения к базе данных
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// GetSongStats возвращает статистику текста песни по её идентификатору
+func (s *SongService) GetSongStats(ctx context.Context, id string, top int) (_ *models.SongStatsResponse, err error) {
+	ctx, span := tracing.Start(ctx, "SongService.GetSongStats", attribute.String("song.id", id))
+	defer tracing.End(span, &err)
+
+	s.Logger.InfoContext(ctx, "Calculating lyrics stats for song", "id", id)
+
+	if !uuidPattern.MatchString(id) {
+		return nil, ErrInvalidSongID
+	}
+	if top < 1 || top > MaxTopWords {
+		return nil, ErrInvalidTop
+	}
+
+	song, err := s.Repo.GetSongByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSongNotFound
+		}
+		s.Logger.ErrorContext(ctx, "Failed to fetch song", "error", err)
+		return nil, err
+	}
+
+	var counter lyricsCounter
+	counter.add(song.Text)
+
+	return &models.SongStatsResponse{
+		ID:          song.ID,
+		Group:       song.GroupName,
+		SongName:    song.SongName,
+		LyricsStats: counter.stats(top),
+	}, nil
+}
+
+// GetGroupStats возвращает статистику, объединённую по всем песням группы
+func (s *SongService) GetGroupStats(ctx context.Context, group string, top int) (_ *models.GroupStatsResponse, err error) {
+	ctx, span := tracing.Start(ctx, "SongService.GetGroupStats")
+	defer tracing.End(span, &err)
+
+	s.Logger.InfoContext(ctx, "Calculating lyrics stats", "group", group)
+
+	if top < 1 || top > MaxTopWords {
+		return nil, ErrInvalidTop
+	}
+
+	songs, err := s.Repo.GetSongsByGroup(ctx, group)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to fetch group songs", "error", err)
+		return nil, err
+	}
+	if len(songs) == 0 {
+		return nil, ErrSongNotFound
+	}
+
+	var counter lyricsCounter
+	for _, song := range songs {
+		counter.add(song.Text)
+	}
+
+	return &models.GroupStatsResponse{
+		Group:       group,
+		Songs:       len(songs),
+		LyricsStats: counter.stats(top),
+	}, nil
+}
+
+// lyricsCounter накапливает статистику по одному или нескольким текстам
+type lyricsCounter struct {
+	lines         int
+	verses        int
+	words         int
+	repeatedWords int
+	counts        map[string]int
+}
+
+// add учитывает текст песни. Куплет, уже встречавшийся в этой же песне, считается повтором
+func (c *lyricsCounter) add(text string) {
+	if c.counts == nil {
+		c.counts = make(map[string]int)
+	}
+
+	seen := make(map[string]bool)
+	for _, verse := range splitVerses(text) {
+		lines := splitLines(verse)
+		if len(lines) == 0 {
+			continue
+		}
+		c.verses++
+		c.lines += len(lines)
+
+		var verseWords []string
+		for _, line := range lines {
+			verseWords = append(verseWords, tokenize(line)...)
+		}
+		c.words += len(verseWords)
+		for _, word := range verseWords {
+			c.counts[word]++
+		}
+
+		key := strings.Join(verseWords, " ")
+		if seen[key] {
+			c.repeatedWords += len(verseWords)
+		}
+		seen[key] = true
+	}
+}
+
+// stats возвращает накопленную статистику с top самыми частыми словами
+func (c *lyricsCounter) stats(top int) models.LyricsStats {
+	result := models.LyricsStats{
+		Lines:       c.lines,
+		Verses:      c.verses,
+		Words:       c.words,
+		UniqueWords: len(c.counts),
+		TopWords:    []models.WordCount{},
+	}
+	if c.words > 0 {
+		result.UniqueWordRatio = float64(len(c.counts)) / float64(c.words)
+		result.RepetitionScore = float64(c.repeatedWords) / float64(c.words)
+	}
+
+	for word, count := range c.counts {
+		if !stopWords[word] {
+			result.TopWords = append(result.TopWords, models.WordCount{Word: word, Count: count})
+		}
+	}
+	sort.Slice(result.TopWords, func(i, j int) bool {
+		if result.TopWords[i].Count != result.TopWords[j].Count {
+			return result.TopWords[i].Count > result.TopWords[j].Count
+		}
+		return result.TopWords[i].Word < result.TopWords[j].Word
+	})
+	if len(result.TopWords) > top {
+		result.TopWords = result.TopWords[:top]
+	}
+	return result
+}
+
+// tokenize разбивает строку на слова в нижнем регистре; апостроф внутри слова сохраняется
+func tokenize(line string) []string {
+	line = strings.ReplaceAll(strings.ToLower(line), "’", "'")
+	fields := strings.FieldsFunc(line, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	words := fields[:0]
+	for _, field := range fields {
+		if field = strings.Trim(field, "'"); field != "" {
+			words = append(words, field)
+		}
+	}
+	return words
+}
+
+// tokenizeText разбивает весь текст песни на слова с учётом экранированных переводов строк
+func tokenizeText(text string) []string {
+	var words []string
+	for _, verse := range splitVerses(text) {
+		for _, line := range splitLines(verse) {
+			words = append(words, tokenize(line)...)
+		}
+	}
+	return words
+}
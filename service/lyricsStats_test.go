@@ -0,0 +1,126 @@
+package service
+
+import (
+	"math"
+	"slices"
+	"song-libary/models"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{name: "punctuation and case", line: "Hello, World!", want: []string{"hello", "world"}},
+		{name: "apostrophe inside word", line: "Don't stop me now", want: []string{"don't", "stop", "me", "now"}},
+		{name: "typographic apostrophe", line: "Don’t", want: []string{"don't"}},
+		{name: "apostrophes around word", line: "'Cause rock 'n' roll", want: []string{"cause", "rock", "n", "roll"}},
+		{name: "cyrillic", line: "Мороз и солнце — день чудесный!", want: []string{"мороз", "и", "солнце", "день", "чудесный"}},
+		{name: "digits", line: "99 Luftballons", want: []string{"99", "luftballons"}},
+		{name: "punctuation only", line: "--- !!! '''", want: nil},
+		{name: "empty", line: "", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenize(tt.line); !slices.Equal(got, tt.want) {
+				t.Errorf("tokenize(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLyricsCounter(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		top   int
+		want  models.LyricsStats
+	}{
+		{
+			name:  "single verse",
+			texts: []string{`Hello hello world\nGoodbye world`},
+			top:   2,
+			want: models.LyricsStats{
+				Lines: 2, Verses: 1, Words: 5, UniqueWords: 3, UniqueWordRatio: 0.6,
+				TopWords: []models.WordCount{{Word: "hello", Count: 2}, {Word: "world", Count: 2}},
+			},
+		},
+		{
+			name:  "repeated verse",
+			texts: []string{`Love love\nSong\n\nNight falls\n\nLove love\nSong`},
+			top:   10,
+			want: models.LyricsStats{
+				Lines: 5, Verses: 3, Words: 8, UniqueWords: 4, UniqueWordRatio: 0.5, RepetitionScore: 3.0 / 8,
+				TopWords: []models.WordCount{{Word: "love", Count: 4}, {Word: "song", Count: 2}, {Word: "falls", Count: 1}, {Word: "night", Count: 1}},
+			},
+		},
+		{
+			name:  "repeats across songs are not counted",
+			texts: []string{"Same verse", "Same verse"},
+			top:   10,
+			want: models.LyricsStats{
+				Lines: 2, Verses: 2, Words: 4, UniqueWords: 2, UniqueWordRatio: 0.5,
+				TopWords: []models.WordCount{{Word: "same", Count: 2}, {Word: "verse", Count: 2}},
+			},
+		},
+		{
+			name:  "apostrophes and stop words",
+			texts: []string{`Don't stop\nDon’t stop`},
+			top:   10,
+			want: models.LyricsStats{
+				Lines: 2, Verses: 1, Words: 4, UniqueWords: 2, UniqueWordRatio: 0.5,
+				TopWords: []models.WordCount{{Word: "stop", Count: 2}},
+			},
+		},
+		{
+			name:  "cyrillic",
+			texts: []string{`Мороз и солнце\nДень чудесный`},
+			top:   10,
+			want: models.LyricsStats{
+				Lines: 2, Verses: 1, Words: 5, UniqueWords: 5, UniqueWordRatio: 1,
+				TopWords: []models.WordCount{{Word: "день", Count: 1}, {Word: "мороз", Count: 1}, {Word: "солнце", Count: 1}, {Word: "чудесный", Count: 1}},
+			},
+		},
+		{
+			name:  "blank verses and lines skipped",
+			texts: []string{`First line\n  \n\n\n\nSecond line`},
+			top:   10,
+			want: models.LyricsStats{
+				Lines: 2, Verses: 2, Words: 4, UniqueWords: 3, UniqueWordRatio: 0.75,
+				TopWords: []models.WordCount{{Word: "line", Count: 2}, {Word: "first", Count: 1}, {Word: "second", Count: 1}},
+			},
+		},
+		{
+			name:  "empty text",
+			texts: []string{""},
+			top:   10,
+			want:  models.LyricsStats{TopWords: []models.WordCount{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var counter lyricsCounter
+			for _, text := range tt.texts {
+				counter.add(text)
+			}
+			got := counter.stats(tt.top)
+
+			if got.Lines != tt.want.Lines || got.Verses != tt.want.Verses || got.Words != tt.want.Words || got.UniqueWords != tt.want.UniqueWords {
+				t.Errorf("lines, verses, words, unique = %d, %d, %d, %d, want %d, %d, %d, %d",
+					got.Lines, got.Verses, got.Words, got.UniqueWords,
+					tt.want.Lines, tt.want.Verses, tt.want.Words, tt.want.UniqueWords)
+			}
+			if math.Abs(got.UniqueWordRatio-tt.want.UniqueWordRatio) > 1e-9 {
+				t.Errorf("unique word ratio = %v, want %v", got.UniqueWordRatio, tt.want.UniqueWordRatio)
+			}
+			if math.Abs(got.RepetitionScore-tt.want.RepetitionScore) > 1e-9 {
+				t.Errorf("repetition score = %v, want %v", got.RepetitionScore, tt.want.RepetitionScore)
+			}
+			if got.TopWords == nil || !slices.Equal(got.TopWords, tt.want.TopWords) {
+				t.Errorf("top words = %+v, want %+v", got.TopWords, tt.want.TopWords)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import "strings"
+
+// minRhymeSuffix — минимальная длина общего окончания (в буквах), при которой строки считаются рифмующимися
+const minRhymeSuffix = 2
+
+// vowels содержит гласные английского и русского алфавитов
+const vowels = "aeiouyаеёиоуыэюя"
+
+// rhymeScheme возвращает схему рифмовки куплета, например AABB или ABAB.
+// Каждой строке назначается буква первой рифмующейся с ней предыдущей строки или новая буква
+func rhymeScheme(verse string) string {
+	var endings []string
+	var scheme []byte
+	next := byte('A')
+
+	for _, line := range splitLines(verse) {
+		words := tokenize(line)
+		if len(words) == 0 {
+			continue
+		}
+		ending := rhymeEnding(words[len(words)-1])
+
+		letter := byte(0)
+		for i, previous := range endings {
+			if rhymes(previous, ending) {
+				letter = scheme[i]
+				break
+			}
+		}
+		if letter == 0 {
+			letter = next
+			if next < 'Z' {
+				next++
+			}
+		}
+
+		endings = append(endings, ending)
+		scheme = append(scheme, letter)
+	}
+	return string(scheme)
+}
+
+// rhymeSchemes возвращает схемы рифмовки для списка куплетов
+func rhymeSchemes(verses []string) []string {
+	schemes := make([]string, len(verses))
+	for i, verse := range verses {
+		schemes[i] = rhymeScheme(verse)
+	}
+	return schemes
+}
+
+// rhymeEnding нормализует последнее слово строки: ё заменяется на е,
+// а у английских слов отбрасывается окончание множественного числа (lights → light)
+func rhymeEnding(word string) string {
+	word = strings.ReplaceAll(word, "ё", "е")
+	if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// rhymes проверяет, что у слов есть общее окончание не короче minRhymeSuffix, содержащее гласную.
+// Такая эвристика не требует словаря ударений и одинаково работает для английского и русского
+func rhymes(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	n, hasVowel := 0, false
+	for n < len(ra) && n < len(rb) && ra[len(ra)-1-n] == rb[len(rb)-1-n] {
+		if strings.ContainsRune(vowels, ra[len(ra)-1-n]) {
+			hasVowel = true
+		}
+		n++
+	}
+	return n >= minRhymeSuffix && hasVowel
+}
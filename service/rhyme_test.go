@@ -0,0 +1,50 @@
+package service
+
+import "testing"
+
+func TestRhymeScheme(t *testing.T) {
+	tests := []struct {
+		name  string
+		verse string
+		want  string
+	}{
+		{name: "couplets", verse: `I see the light\nIn the night\nYou walk away\nAnd never stay`, want: "AABB"},
+		{name: "alternating", verse: "The night is long\nI walk alone\nI sing my song\nWith heart of stone", want: "ABAB"},
+		{name: "russian", verse: `Мороз и солнце\nДень чудесный\nЕщё ты дремлешь\nДруг прелестный`, want: "ABCB"},
+		{name: "plural ending", verse: `City lights\nSummer night`, want: "AA"},
+		{name: "yo replaced", verse: `Всё идёт\nКто поёт`, want: "AA"},
+		{name: "trailing punctuation", verse: `Hold me tight!\nAll right.`, want: "AA"},
+		{name: "blank lines skipped", verse: "Stay\n\n  \nAway", want: "AA"},
+		{name: "consonant suffix is not a rhyme", verse: `Cold mist\nTwist and twist\nA cyst`, want: "AAB"},
+		{name: "empty", verse: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rhymeScheme(tt.verse); got != tt.want {
+				t.Errorf("rhymeScheme(%q) = %q, want %q", tt.verse, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRhymes(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "light", b: "night", want: true},
+		{a: "day", b: "stay", want: true},
+		{a: "mist", b: "list", want: true},
+		{a: "mist", b: "cyst"}, // Общее окончание без гласной
+		{a: "cat", b: "dog"},
+		{a: "st", b: "st", want: true},
+		{a: "весна", b: "луна", want: true},
+		{a: "дом", b: "том", want: true},
+		{a: "сад", b: "дождь"},
+	}
+	for _, tt := range tests {
+		if got := rhymes(tt.a, tt.b); got != tt.want {
+			t.Errorf("rhymes(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"math"
+	"song-libary/models"
+	"song-libary/tracing"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrInvalidSimilarLimit = Invalid("invalid_limit", "limit must be between 1 and 50")
+	ErrInvalidBoost        = Invalid("invalid_boost", "group_boost and genre_boost must be between 0 and 1")
+)
+
+// Ограничения выдачи похожих песен
+const (
+	DefaultSimilarLimit = 10
+	MaxSimilarLimit     = 50
+)
+
+// indexBatchSize — размер порции песен при построении индекса
+const indexBatchSize = 500
+
+// SimilarityOptions задаёт параметры поиска похожих песен
+type SimilarityOptions struct {
+	Limit      int     // Количество песен в ответе
+	GroupBoost float64 // Надбавка к оценке для песен той же группы
+	GenreBoost float64 // Надбавка к оценке для песен с общим жанром
+}
+
+// indexedSong хранит частоты слов песни в индексе
+type indexedSong struct {
+	id       string
+	group    string
+	songName string
+	terms    map[string]int
+}
+
+// SimilarityIndex — TF-IDF индекс текстов песен в памяти процесса.
+// Индекс обновляется при добавлении, изменении и удалении песен
+type SimilarityIndex struct {
+	mu    sync.RWMutex
+	songs map[string]*indexedSong
+	df    map[string]int // Число песен, содержащих слово
+}
+
+func NewSimilarityIndex() *SimilarityIndex {
+	return &SimilarityIndex{songs: make(map[string]*indexedSong), df: make(map[string]int)}
+}
+
+// Upsert добавляет песню в индекс или заменяет её прежнюю версию
+func (idx *SimilarityIndex) Upsert(song *models.Song) {
+	terms := make(map[string]int)
+	for _, word := range tokenizeText(song.Text) {
+		if !stopWords[word] {
+			terms[word]++
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(song.ID)
+	idx.songs[song.ID] = &indexedSong{id: song.ID, group: song.GroupName, songName: song.SongName, terms: terms}
+	for term := range terms {
+		idx.df[term]++
+	}
+}
+
+// Remove удаляет песню из индекса
+func (idx *SimilarityIndex) Remove(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(id)
+}
+
+func (idx *SimilarityIndex) remove(id string) {
+	song, ok := idx.songs[id]
+	if !ok {
+		return
+	}
+	for term := range song.terms {
+		if idx.df[term]--; idx.df[term] == 0 {
+			delete(idx.df, term)
+		}
+	}
+	delete(idx.songs, id)
+}
+
+// Len возвращает количество песен в индексе
+func (idx *SimilarityIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.songs)
+}
+
+// Similar возвращает песни, упорядоченные по косинусной близости TF-IDF векторов к песне id.
+// sameGenre содержит песни с общим жанром для надбавки GenreBoost. Второе значение false, если песни нет в индексе
+func (idx *SimilarityIndex) Similar(id string, opts SimilarityOptions, sameGenre map[string]bool) ([]models.SimilarSong, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	target, ok := idx.songs[id]
+	if !ok {
+		return nil, false
+	}
+	targetVector, targetNorm := idx.vector(target)
+
+	var result []models.SimilarSong
+	for _, song := range idx.songs {
+		if song.id == id {
+			continue
+		}
+
+		similarity := 0.0
+		if targetNorm > 0 {
+			vector, norm := idx.vector(song)
+			if norm > 0 {
+				dot := 0.0
+				for term, weight := range vector {
+					dot += weight * targetVector[term]
+				}
+				similarity = dot / (norm * targetNorm)
+			}
+		}
+
+		score := similarity
+		if song.group == target.group {
+			score += opts.GroupBoost
+		}
+		if sameGenre[song.id] {
+			score += opts.GenreBoost
+		}
+		if score <= 0 {
+			continue
+		}
+
+		result = append(result, models.SimilarSong{
+			ID:         song.id,
+			Group:      song.group,
+			SongName:   song.songName,
+			Similarity: similarity,
+			Score:      score,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].ID < result[j].ID
+	})
+	if len(result) > opts.Limit {
+		result = result[:opts.Limit]
+	}
+	return result, true
+}
+
+// vector возвращает TF-IDF вектор песни и его длину. Вызывается под блокировкой
+func (idx *SimilarityIndex) vector(song *indexedSong) (map[string]float64, float64) {
+	total := float64(len(idx.songs))
+	vector := make(map[string]float64, len(song.terms))
+	norm := 0.0
+	for term, count := range song.terms {
+		weight := (1 + math.Log(float64(count))) * (math.Log((1+total)/(1+float64(idx.df[term]))) + 1)
+		vector[term] = weight
+		norm += weight * weight
+	}
+	return vector, math.Sqrt(norm)
+}
+
+// BuildSimilarityIndex заполняет индекс похожих песен всеми песнями из базы данных
+func (s *SongService) BuildSimilarityIndex(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "SongService.BuildSimilarityIndex")
+	defer tracing.End(span, &err)
+
+	s.Logger.InfoContext(ctx, "Building similarity index")
+
+	afterID := ""
+	for {
+		songs, err := s.Repo.ListSongs(ctx, afterID, indexBatchSize)
+		if err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to load songs for similarity index", "error", err)
+			return err
+		}
+		if len(songs) == 0 {
+			break
+		}
+		for _, song := range songs {
+			s.Similarity.Upsert(song)
+			afterID = song.ID
+		}
+	}
+
+	s.Logger.InfoContext(ctx, "Similarity index built", "songs", s.Similarity.Len())
+	return nil
+}
+
+// GetSimilarSongs возвращает песни с наиболее близкими текстами
+func (s *SongService) GetSimilarSongs(ctx context.Context, id string, opts SimilarityOptions) (_ []models.SimilarSong, err error) {
+	ctx, span := tracing.Start(ctx, "SongService.GetSimilarSongs", attribute.String("song.id", id))
+	defer tracing.End(span, &err)
+
+	s.Logger.InfoContext(ctx, "Fetching similar songs", "id", id, "opts", opts)
+
+	if !uuidPattern.MatchString(id) {
+		return nil, ErrInvalidSongID
+	}
+	if opts.Limit < 1 || opts.Limit > MaxSimilarLimit {
+		return nil, ErrInvalidSimilarLimit
+	}
+	if !validBoost(opts.GroupBoost) || !validBoost(opts.GenreBoost) {
+		return nil, ErrInvalidBoost
+	}
+
+	var sameGenre map[string]bool
+	if opts.GenreBoost > 0 {
+		ids, err := s.Repo.GetSongsSharingGenre(ctx, id)
+		if err != nil {
+			s.Logger.ErrorContext(ctx, "Failed to fetch songs for genre boost", "error", err)
+			return nil, err
+		}
+		sameGenre = makeWordSet(ids...)
+	}
+
+	similar, ok := s.Similarity.Similar(id, opts, sameGenre)
+	if !ok {
+		return nil, ErrSongNotFound
+	}
+	if similar == nil {
+		similar = []models.SimilarSong{}
+	}
+	return similar, nil
+}
+
+// validBoost проверяет, что надбавка лежит в [0, 1]. NaN не проходит ни одно сравнение,
+// поэтому проверяется явно: иначе все оценки стали бы NaN, а порядок выдачи — неопределённым
+func validBoost(boost float64) bool {
+	return !math.IsNaN(boost) && !math.IsInf(boost, 0) && boost >= 0 && boost <= 1
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"song-libary/models"
+	"testing"
+)
+
+const (
+	fireID  = "00000000-0000-0000-0000-000000000001"
+	flameID = "00000000-0000-0000-0000-000000000002"
+	oceanID = "00000000-0000-0000-0000-000000000003"
+	riverID = "00000000-0000-0000-0000-000000000004"
+)
+
+func newTestIndex() *SimilarityIndex {
+	idx := NewSimilarityIndex()
+	idx.Upsert(&models.Song{ID: fireID, GroupName: "Embers", SongName: "Fire", Text: `Fire burning bright\nFire burning forever`})
+	idx.Upsert(&models.Song{ID: flameID, GroupName: "Sparks", SongName: "Flame", Text: `Fire burning slowly\nCandle flame`})
+	idx.Upsert(&models.Song{ID: oceanID, GroupName: "Embers", SongName: "Ocean", Text: `Ocean waves rolling\nSalt water`})
+	idx.Upsert(&models.Song{ID: riverID, GroupName: "Tides", SongName: "River", Text: `River water rolling\nStones below`})
+	return idx
+}
+
+func TestSimilarityIndexSimilar(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		opts      SimilarityOptions
+		sameGenre map[string]bool
+		want      []string
+	}{
+		{name: "shared words only", id: fireID, opts: SimilarityOptions{Limit: 10}, want: []string{flameID}},
+		{name: "group boost adds same group", id: fireID, opts: SimilarityOptions{Limit: 10, GroupBoost: 0.1}, want: []string{flameID, oceanID}},
+		{
+			name:      "genre boost adds same genre",
+			id:        fireID,
+			opts:      SimilarityOptions{Limit: 10, GenreBoost: 0.05},
+			sameGenre: map[string]bool{riverID: true},
+			want:      []string{flameID, riverID},
+		},
+		{name: "boost can outrank similarity", id: oceanID, opts: SimilarityOptions{Limit: 10, GroupBoost: 1}, want: []string{fireID, riverID}},
+		{name: "limit", id: oceanID, opts: SimilarityOptions{Limit: 1, GroupBoost: 1}, want: []string{fireID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			similar, ok := newTestIndex().Similar(tt.id, tt.opts, tt.sameGenre)
+			if !ok {
+				t.Fatal("song not found in index")
+			}
+			var got []string
+			for _, song := range similar {
+				got = append(got, song.ID)
+				if song.Similarity < 0 || song.Similarity > 1+1e-9 {
+					t.Errorf("similarity of %s = %v, want within [0, 1]", song.ID, song.Similarity)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Similar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimilarityIndexUpdates(t *testing.T) {
+	idx := newTestIndex()
+
+	// После изменения текста песня перестаёт быть похожей
+	idx.Upsert(&models.Song{ID: flameID, GroupName: "Sparks", SongName: "Flame", Text: "Quiet snow"})
+	if similar, _ := idx.Similar(fireID, SimilarityOptions{Limit: 10}, nil); len(similar) != 0 {
+		t.Errorf("Similar() after update = %+v, want none", similar)
+	}
+	if idx.df["burning"] != 1 {
+		t.Errorf("document frequency of replaced word = %d, want 1", idx.df["burning"])
+	}
+
+	idx.Remove(riverID)
+	if _, ok := idx.Similar(riverID, SimilarityOptions{Limit: 10}, nil); ok {
+		t.Error("removed song still in index")
+	}
+	if idx.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", idx.Len())
+	}
+}
+
+func TestGetSimilarSongsValidation(t *testing.T) {
+	s := &SongService{Similarity: newTestIndex(), Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	tests := []struct {
+		name string
+		id   string
+		opts SimilarityOptions
+		want error
+	}{
+		{name: "invalid id", id: "fire", opts: SimilarityOptions{Limit: 10}, want: ErrInvalidSongID},
+		{name: "zero limit", id: fireID, opts: SimilarityOptions{}, want: ErrInvalidSimilarLimit},
+		{name: "limit too large", id: fireID, opts: SimilarityOptions{Limit: MaxSimilarLimit + 1}, want: ErrInvalidSimilarLimit},
+		{name: "negative boost", id: fireID, opts: SimilarityOptions{Limit: 10, GroupBoost: -0.1}, want: ErrInvalidBoost},
+		{name: "boost too large", id: fireID, opts: SimilarityOptions{Limit: 10, GroupBoost: 1.5}, want: ErrInvalidBoost},
+		{name: "NaN group boost", id: fireID, opts: SimilarityOptions{Limit: 10, GroupBoost: math.NaN()}, want: ErrInvalidBoost},
+		{name: "NaN genre boost", id: fireID, opts: SimilarityOptions{Limit: 10, GenreBoost: math.NaN()}, want: ErrInvalidBoost},
+		{name: "infinite boost", id: fireID, opts: SimilarityOptions{Limit: 10, GenreBoost: math.Inf(1)}, want: ErrInvalidBoost},
+		{name: "unknown song", id: "00000000-0000-0000-0000-0000000000ff", opts: SimilarityOptions{Limit: 10}, want: ErrSongNotFound},
+		{name: "valid", id: fireID, opts: SimilarityOptions{Limit: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.GetSimilarSongs(context.Background(), tt.id, tt.opts)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("GetSimilarSongs() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
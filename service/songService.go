@@ -1,125 +1,297 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
-	"log"
+	"log/slog"
+	"song-libary/langdetect"
+	"song-libary/metrics"
 	"song-libary/models"
+	"song-libary/profanity"
 	"song-libary/repository"
+	"song-libary/tracing"
 	"strings"
 )
 
-var ErrSongNotFound = errors.New("song not found")
+var (
+	ErrSongNotFound      = NotFound("song_not_found", "song not found")
+	ErrInvalidTagsMatch  = Invalid("invalid_tags_match", "tags_match must be either \"any\" or \"all\"")
+	ErrInvalidLinkStatus = Invalid("invalid_link_status", "link_status must be one of \"unchecked\", \"ok\" or \"broken\"")
+)
 
 type SongService struct {
-	Repo repository.SongRepository
+	Repo       repository.SongRepository
+	Detector   *langdetect.Detector
+	Profanity  *profanity.Filter
+	Similarity *SimilarityIndex
+	Logger     *slog.Logger
 }
 
-func NewSongService(repo repository.SongRepository) *SongService {
-	return &SongService{Repo: repo}
+func NewSongService(repo repository.SongRepository, logger *slog.Logger) *SongService {
+	return &SongService{
+		Repo:       repo,
+		Detector:   langdetect.Default(),
+		Profanity:  profanity.Default(),
+		Similarity: NewSimilarityIndex(),
+		Logger:     logger,
+	}
 }
 
-func (s *SongService) AddSong(group, song, text, releaseDate, link string) (*models.Song, error) {
-	log.Printf("[INFO] Adding new song: group=%s, song=%s", group, song)
+// AddSong проверяет метаданные трека и сохраняет новую песню
+func (s *SongService) AddSong(ctx context.Context, req models.AddSongRequest) (_ *models.Song, err error) {
+	ctx, span := tracing.Start(ctx, "SongService.AddSong")
+	defer tracing.End(span, &err)
+
+	s.Logger.InfoContext(ctx, "Adding new song", "group", req.Group, "song", req.Song)
+
+	isrc, err := normalizeISRC(req.ISRC)
+	if err != nil {
+		return nil, withField(err, "isrc")
+	}
+	key, err := normalizeKey(req.Key)
+	if err != nil {
+		return nil, withField(err, "key")
+	}
+	if err := validateTrackMetadata(req.DurationMs, req.BPM); err != nil {
+		return nil, withField(err, metadataField(err, ""))
+	}
+	link, err := detectSongLink(req.Link)
+	if err != nil {
+		return nil, withField(err, "link")
+	}
+	language, err := s.resolveLanguage(req.Text, req.Language)
+	if err != nil {
+		return nil, withField(err, "language")
+	}
+	explicit := s.Profanity.Check(req.Text)
 
 	newSong := &models.Song{
-		GroupName:   group,
-		SongName:    song,
-		Text:        text,
-		ReleaseDate: releaseDate,
-		Link:        link,
+		GroupName:   req.Group,
+		SongName:    req.Song,
+		Text:        req.Text,
+		ReleaseDate: req.ReleaseDate,
+		Link:        strings.TrimSpace(req.Link),
+		DurationMs:  req.DurationMs,
+		ISRC:        isrc,
+		BPM:         req.BPM,
+		Key:         key,
+		Explicit:    req.Explicit || explicit.Explicit,
+
+		ExplicitLines: explicit.Lines,
+
+		Language:           language.Language,
+		LanguageConfidence: language.Confidence,
+		LanguageManual:     language.Manual,
 	}
 
-	if err := s.Repo.SaveSong(newSong); err != nil {
-		log.Printf("[ERROR] Failed to add song: %v", err)
+	if err := s.Repo.SaveSong(ctx, newSong, link); err != nil {
+		if errors.Is(err, repository.ErrDuplicateISRC) {
+			return nil, ErrDuplicateISRC
+		}
+		s.Logger.ErrorContext(ctx, "Failed to add song", "error", err)
 		return nil, err
 	}
+	s.Similarity.Upsert(newSong)
 
-	log.Printf("[INFO] Song added successfully: %+v", newSong)
+	metrics.SongsAdded.Inc()
+	s.Logger.InfoContext(ctx, "Song added successfully", "id", newSong.ID)
 	return newSong, nil
 }
 
 // DeleteSongByNameAndGroup удаляет песню по названию
-func (s *SongService) DeleteSongByNameAndGroup(songName, group string) error {
-	log.Printf("[INFO] Deleting song with name: %s", songName)
+func (s *SongService) DeleteSongByNameAndGroup(ctx context.Context, songName, group string) (err error) {
+	ctx, span := tracing.Start(ctx, "SongService.DeleteSongByNameAndGroup")
+	defer tracing.End(span, &err)
 
-	if err := s.Repo.DeleteBySongNameAndGroup(songName, group); err != nil {
+	s.Logger.InfoContext(ctx, "Deleting song", "song", songName)
+
+	id, err := s.Repo.DeleteBySongNameAndGroup(ctx, songName, group)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			log.Printf("[INFO] Song not found: %s", songName)
+			s.Logger.InfoContext(ctx, "Song not found", "song", songName)
 			return ErrSongNotFound
 		}
-		log.Printf("[ERROR] Failed to delete song: %v", err)
+		s.Logger.ErrorContext(ctx, "Failed to delete song", "error", err)
 		return err
 	}
 
-	log.Printf("[INFO] Song deleted successfully: %s", songName)
+	s.Similarity.Remove(id)
+
+	metrics.SongsDeleted.Inc()
+	s.Logger.InfoContext(ctx, "Song deleted successfully", "song", songName)
 	return nil
 }
 
 // UpdateSong изменяет данные песни
-func (s *SongService) UpdateSong(req models.UpdateSongRequest) error {
-	log.Printf("[INFO] Updating song: oldName=%s, oldGroup=%s, newGroup=%s, newName=%s", req.OldSongName, req.OldGroup, req.NewGroup, req.NewSongName)
+func (s *SongService) UpdateSong(ctx context.Context, req models.UpdateSongRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "SongService.UpdateSong")
+	defer tracing.End(span, &err)
+
+	s.Logger.InfoContext(ctx, "Updating song", "old_song_name", req.OldSongName, "old_group", req.OldGroup, "new_group", req.NewGroup, "new_song_name", req.NewSongName)
+
+	isrc, err := normalizeISRC(req.NewISRC)
+	if err != nil {
+		return withField(err, "new_isrc")
+	}
+	key, err := normalizeKey(req.NewKey)
+	if err != nil {
+		return withField(err, "new_key")
+	}
+	if err := validateTrackMetadata(req.NewDurationMs, req.NewBPM); err != nil {
+		return withField(err, metadataField(err, "new_"))
+	}
+	link, err := detectSongLink(req.NewLink)
+	if err != nil {
+		return withField(err, "new_link")
+	}
+	language, err := s.resolveLanguage(req.NewText, req.NewLanguage)
+	if err != nil {
+		return withField(err, "new_language")
+	}
+	explicit := s.Profanity.Check(req.NewText)
+
+	song := &models.Song{
+		GroupName:   req.NewGroup,
+		SongName:    req.NewSongName,
+		Text:        req.NewText,
+		ReleaseDate: req.NewReleaseDate,
+		Link:        strings.TrimSpace(req.NewLink),
+		DurationMs:  req.NewDurationMs,
+		ISRC:        isrc,
+		BPM:         req.NewBPM,
+		Key:         key,
+		Explicit:    req.NewExplicit || explicit.Explicit,
+
+		ExplicitLines: explicit.Lines,
 
-	if err := s.Repo.UpdateSong(req); err != nil {
+		Language:           language.Language,
+		LanguageConfidence: language.Confidence,
+		LanguageManual:     language.Manual,
+	}
+
+	if err := s.Repo.UpdateSong(ctx, req.OldSongName, req.OldGroup, song, link); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			log.Printf("[INFO] Song not found for update: %s", req.OldSongName)
+			s.Logger.InfoContext(ctx, "Song not found for update", "old_song_name", req.OldSongName)
 			return ErrSongNotFound
 		}
-		log.Printf("[ERROR] Failed to update song: %v", err)
+		if errors.Is(err, repository.ErrDuplicateISRC) {
+			return ErrDuplicateISRC
+		}
+		s.Logger.ErrorContext(ctx, "Failed to update song", "error", err)
 		return err
 	}
+	s.Similarity.Upsert(song)
 
-	log.Printf("[INFO] Song updated successfully: oldName=%s, oldGroup=%s", req.OldSongName, req.OldGroup)
+	metrics.SongsUpdated.Inc()
+	s.Logger.InfoContext(ctx, "Song updated successfully", "old_song_name", req.OldSongName, "old_group", req.OldGroup)
 	return nil
 }
 
 // GetSongs возвращает песни с учетом фильтров и пагинации
-func (s *SongService) GetSongs(params models.FilterParams) ([]*models.Song, error) {
-	log.Printf("[INFO] Fetching songs with params: %+v", params)
-	return s.Repo.FindSongs(params)
-}
+func (s *SongService) GetSongs(ctx context.Context, params models.FilterParams) (_ []*models.Song, err error) {
+	ctx, span := tracing.Start(ctx, "SongService.GetSongs")
+	defer tracing.End(span, &err)
 
-// GetSongText возвращает текст песни с учетом пагинации
-func (s *SongService) GetSongText(songName, group string, limit, offset int) (models.SongTextResponse, error) {
-	log.Printf("[INFO] Fetching text for song: %s with pagination: limit=%d, offset=%d", songName, limit, offset)
+	s.Logger.InfoContext(ctx, "Fetching songs", "params", params)
 
-	text, err := s.Repo.GetSongTextByNameAndGroup(songName, group)
+	params, err = normalizeFilter(params)
 	if err != nil {
-		log.Printf("[ERROR] Failed to fetch song text: %v", err)
-		return models.SongTextResponse{}, ErrSongNotFound
+		return nil, err
 	}
+	return s.Repo.FindSongs(ctx, params)
+}
 
-	// Разделяем текст на куплеты
-	verses := strings.Split(text, "\\n\\n")
-	total := len(verses)
+// normalizeFilter проверяет параметры фильтрации и приводит их к виду, ожидаемому репозиторием
+func normalizeFilter(params models.FilterParams) (models.FilterParams, error) {
+	if err := validateRange(params.BPMMin, params.BPMMax); err != nil {
+		return params, withField(err, "bpm_min")
+	}
+	if err := validateRange(params.DurationMin, params.DurationMax); err != nil {
+		return params, withField(err, "duration_min")
+	}
+	key, err := normalizeKey(params.Key)
+	if err != nil {
+		return params, withField(err, "key")
+	}
+	params.Key = key
+	params.Language = normalizeLang(params.Language)
 
-	// Применяем пагинацию
-	start := offset
-	if start >= total {
-		start = total
+	switch params.LinkStatus {
+	case "", models.LinkStatusUnchecked, models.LinkStatusOK, models.LinkStatusBroken:
+	default:
+		return params, withField(ErrInvalidLinkStatus, "link_status")
 	}
-	end := start + limit
-	if end > total {
-		end = total
+
+	params.Genre = normalizeName(params.Genre)
+	params.Tags = normalizeNames(params.Tags)
+	switch params.TagsMatch {
+	case "":
+		params.TagsMatch = models.TagsMatchAny
+	case models.TagsMatchAny, models.TagsMatchAll:
+	default:
+		return params, withField(ErrInvalidTagsMatch, "tags_match")
 	}
 
+	return params, nil
+}
+
+// GetSongText возвращает текст песни с учетом пагинации; в режиме Clean ненормативная лексика маскируется
+func (s *SongService) GetSongText(ctx context.Context, songName, group string, params models.SongTextParams) (_ models.SongTextResponse, err error) {
+	ctx, span := tracing.Start(ctx, "SongService.GetSongText")
+	defer tracing.End(span, &err)
+
+	s.Logger.InfoContext(ctx, "Fetching song text", "song", songName, "limit", params.Limit, "offset", params.Offset, "clean", params.Clean)
+
+	text, err := s.Repo.GetSongTextByNameAndGroup(ctx, songName, group)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.SongTextResponse{}, ErrSongNotFound
+		}
+		s.Logger.ErrorContext(ctx, "Failed to fetch song text", "error", err)
+		return models.SongTextResponse{}, err
+	}
+
+	// Разделяем текст на куплеты и применяем пагинацию
+	verses := splitVerses(text)
+	total := len(verses)
+	start, end := pageBounds(total, params.Limit, params.Offset)
+
 	paginatedVerses := verses[start:end]
 
 	response := models.SongTextResponse{
 		SongName: songName,
 		Group:    group,
 		Verses:   paginatedVerses,
-		Limit:    limit,
-		Offset:   offset,
+		Limit:    params.Limit,
+		Offset:   params.Offset,
 		Total:    total,
 	}
+	// Схема рифмовки определяется до маскирования, иначе звёздочки исказят окончания строк
+	if params.Rhymes {
+		response.Rhymes = rhymeSchemes(paginatedVerses)
+	}
+	if params.Clean {
+		response.Verses = s.Profanity.MaskAll(paginatedVerses)
+	}
 
-	log.Printf("[INFO] Returning %d verses for song: %s", len(paginatedVerses), songName)
+	s.Logger.InfoContext(ctx, "Returning verses", "count", len(paginatedVerses), "song", songName)
 	return response, nil
 }
 
 // GetSongInfo получает информацию о песне по группе и названию
-func (s *SongService) GetSongInfo(group, songName string) (*models.SongDetail, error) {
-	log.Printf("[INFO] Fetching song info for group: %s, song: %s", group, songName)
-	return s.Repo.GetSongInfo(group, songName)
+func (s *SongService) GetSongInfo(ctx context.Context, group, songName string) (_ *models.SongDetail, err error) {
+	ctx, span := tracing.Start(ctx, "SongService.GetSongInfo")
+	defer tracing.End(span, &err)
+
+	s.Logger.InfoContext(ctx, "Fetching song info", "group", group, "song", songName)
+	detail, err := s.Repo.GetSongInfo(ctx, group, songName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSongNotFound
+		}
+		s.Logger.ErrorContext(ctx, "Failed to fetch song info", "error", err)
+		return nil, err
+	}
+	return detail, nil
 }
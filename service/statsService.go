@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"song-libary/models"
+	"song-libary/repository"
+	"sync"
+	"time"
+)
+
+// Параметры статистики библиотеки
+const (
+	DefaultStatsTTL     = time.Minute      // Время жизни закэшированной статистики
+	statsRefreshTimeout = 30 * time.Second // Предельное время пересчёта, не зависящее от запросов
+	statsTopGroups      = 10               // Количество групп в сводке
+	statsNewest         = 10               // Количество последних добавленных песен
+)
+
+// StatsService считает статистику библиотеки и кэширует её на TTL,
+// чтобы частые запросы дашборда не нагружали базу данных агрегатами.
+// Пересчёт выполняется один раз для всех ожидающих запросов и не прерывается,
+// если запрос, который его начал, отменён
+type StatsService struct {
+	Repo   repository.StatsRepository
+	TTL    time.Duration
+	Now    func() time.Time
+	Logger *slog.Logger
+
+	mu      sync.Mutex
+	cached  *models.CatalogueStats
+	expires time.Time
+	refresh *statsRefresh
+}
+
+// statsRefresh — выполняющийся пересчёт статистики; done закрывается, когда заполнены stats и err
+type statsRefresh struct {
+	done  chan struct{}
+	stats *models.CatalogueStats
+	err   error
+}
+
+func NewStatsService(repo repository.StatsRepository, logger *slog.Logger) *StatsService {
+	return &StatsService{Repo: repo, TTL: DefaultStatsTTL, Now: time.Now, Logger: logger}
+}
+
+// GetStats возвращает сводную статистику библиотеки
+func (s *StatsService) GetStats(ctx context.Context) (*models.CatalogueStats, error) {
+	return s.load(ctx)
+}
+
+// GetGroupCounts возвращает страницу списка групп, упорядоченного по числу песен.
+// Границы страницы проверяет обработчик
+func (s *StatsService) GetGroupCounts(ctx context.Context, limit, offset int) ([]models.GroupCount, error) {
+	stats, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	start, end := pageBounds(len(stats.SongsPerGroup), limit, offset)
+	return stats.SongsPerGroup[start:end], nil
+}
+
+// load возвращает статистику из кэша или дожидается пересчёта, если срок хранения истёк.
+// Пересчёт, начатый другим запросом, не начинается заново; отмена ctx прекращает только ожидание
+func (s *StatsService) load(ctx context.Context) (*models.CatalogueStats, error) {
+	s.mu.Lock()
+	if s.cached != nil && s.Now().Before(s.expires) {
+		stats := s.cached
+		s.mu.Unlock()
+		return stats, nil
+	}
+	refresh := s.refresh
+	if refresh == nil {
+		refresh = &statsRefresh{done: make(chan struct{})}
+		s.refresh = refresh
+		go s.recalculate(context.WithoutCancel(ctx), refresh)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-refresh.done:
+		return refresh.stats, refresh.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// recalculate пересчитывает статистику, сохраняет её в кэш и сообщает результат ожидающим
+func (s *StatsService) recalculate(ctx context.Context, refresh *statsRefresh) {
+	ctx, cancel := context.WithTimeout(ctx, statsRefreshTimeout)
+	defer cancel()
+
+	s.Logger.InfoContext(ctx, "Catalogue stats cache expired, recalculating")
+	stats, err := s.Repo.GetCatalogueStats(ctx, statsNewest)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "Failed to calculate catalogue stats", "error", err)
+	} else {
+		stats.TopGroups = stats.SongsPerGroup[:min(statsTopGroups, len(stats.SongsPerGroup))]
+		stats.SongsPerDecade = decades(stats.SongsPerYear)
+		stats.GeneratedAt = s.Now().UTC()
+	}
+	refresh.stats, refresh.err = stats, err
+
+	s.mu.Lock()
+	if err == nil {
+		s.cached, s.expires = stats, s.Now().Add(s.TTL)
+	}
+	s.refresh = nil
+	s.mu.Unlock()
+	close(refresh.done)
+}
+
+// decades суммирует количество песен по десятилетиям; years упорядочены по возрастанию
+func decades(years []models.PeriodCount) []models.PeriodCount {
+	result := []models.PeriodCount{}
+	for _, year := range years {
+		decade := year.Period - year.Period%10
+		if n := len(result); n > 0 && result[n-1].Period == decade {
+			result[n-1].Songs += year.Songs
+			continue
+		}
+		result = append(result, models.PeriodCount{Period: decade, Songs: year.Songs})
+	}
+	return result
+}
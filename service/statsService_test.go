@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"song-libary/models"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStatsRepo считает пересчёты; если задан release, пересчёт ждёт его закрытия
+type fakeStatsRepo struct {
+	mu      sync.Mutex
+	calls   int
+	errs    []error // Ошибки очередных пересчётов
+	release chan struct{}
+	ctxErr  error // ctx.Err() по завершении последнего пересчёта
+}
+
+func (r *fakeStatsRepo) GetCatalogueStats(ctx context.Context, _ int) (*models.CatalogueStats, error) {
+	r.mu.Lock()
+	r.calls++
+	call := r.calls
+	r.mu.Unlock()
+
+	if r.release != nil {
+		<-r.release
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ctxErr = ctx.Err()
+	if call <= len(r.errs) && r.errs[call-1] != nil {
+		return nil, r.errs[call-1]
+	}
+	return &models.CatalogueStats{Songs: call}, nil
+}
+
+func newTestStatsService(repo *fakeStatsRepo, now *time.Time) *StatsService {
+	service := NewStatsService(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	service.Now = func() time.Time { return *now }
+	return service
+}
+
+func TestStatsServiceCachesUntilTTL(t *testing.T) {
+	start := time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)
+	now := start
+	repo := &fakeStatsRepo{errs: []error{nil, nil, errors.New("connection refused")}}
+	service := newTestStatsService(repo, &now)
+
+	steps := []struct {
+		name          string
+		advance       time.Duration
+		wantSongs     int
+		wantGenerated time.Duration // Время расчёта от start
+		wantErr       bool
+		wantCalls     int
+	}{
+		{name: "first request calculates", wantSongs: 1, wantCalls: 1},
+		{name: "cached within TTL", advance: 59 * time.Second, wantSongs: 1, wantCalls: 1},
+		{name: "recalculated after TTL", advance: time.Second, wantSongs: 2, wantGenerated: time.Minute, wantCalls: 2},
+		{name: "failed refresh is not cached", advance: DefaultStatsTTL, wantErr: true, wantCalls: 3},
+		{name: "next request retries", wantSongs: 4, wantGenerated: 2 * time.Minute, wantCalls: 4},
+	}
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		stats, err := service.GetStats(context.Background())
+		if step.wantErr {
+			if err == nil {
+				t.Fatalf("%s: GetStats() error = nil, want error", step.name)
+			}
+		} else if err != nil {
+			t.Fatalf("%s: GetStats() error = %v", step.name, err)
+		} else if generated := start.Add(step.wantGenerated); stats.Songs != step.wantSongs || !stats.GeneratedAt.Equal(generated) {
+			t.Errorf("%s: songs = %d, generated at %v, want %d, %v", step.name, stats.Songs, stats.GeneratedAt, step.wantSongs, generated)
+		}
+		if repo.calls != step.wantCalls {
+			t.Errorf("%s: repository calls = %d, want %d", step.name, repo.calls, step.wantCalls)
+		}
+	}
+}
+
+func TestStatsServiceRefreshOutlivesCancelledRequest(t *testing.T) {
+	now := time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC)
+	repo := &fakeStatsRepo{release: make(chan struct{})}
+	service := newTestStatsService(repo, &now)
+
+	// Первый запрос начинает пересчёт и отменяется, пока второй ждёт того же пересчёта
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := service.GetStats(firstCtx)
+		firstErr <- err
+	}()
+	for {
+		repo.mu.Lock()
+		started := repo.calls == 1
+		repo.mu.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	type result struct {
+		stats *models.CatalogueStats
+		err   error
+	}
+	second := make(chan result, 1)
+	go func() {
+		stats, err := service.GetStats(context.Background())
+		second <- result{stats, err}
+	}()
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled request error = %v, want context.Canceled", err)
+	}
+	close(repo.release)
+
+	got := <-second
+	if got.err != nil {
+		t.Fatalf("waiting request error = %v", got.err)
+	}
+	if got.stats.Songs != 1 {
+		t.Errorf("songs = %d, want 1", got.stats.Songs)
+	}
+	if repo.calls != 1 {
+		t.Errorf("repository calls = %d, want 1", repo.calls)
+	}
+	if repo.ctxErr != nil {
+		t.Errorf("refresh context error = %v, want nil", repo.ctxErr)
+	}
+}
+
+func TestDecades(t *testing.T) {
+	tests := []struct {
+		name  string
+		years []models.PeriodCount
+		want  []models.PeriodCount
+	}{
+		{name: "empty", want: []models.PeriodCount{}},
+		{
+			name:  "years of one decade",
+			years: []models.PeriodCount{{Period: 1990, Songs: 1}, {Period: 1994, Songs: 2}, {Period: 1999, Songs: 3}},
+			want:  []models.PeriodCount{{Period: 1990, Songs: 6}},
+		},
+		{
+			name:  "decade boundaries",
+			years: []models.PeriodCount{{Period: 1969, Songs: 1}, {Period: 1970, Songs: 2}, {Period: 1979, Songs: 3}, {Period: 1980, Songs: 4}},
+			want:  []models.PeriodCount{{Period: 1960, Songs: 1}, {Period: 1970, Songs: 5}, {Period: 1980, Songs: 4}},
+		},
+		{
+			name:  "gap between decades",
+			years: []models.PeriodCount{{Period: 1955, Songs: 2}, {Period: 2003, Songs: 1}},
+			want:  []models.PeriodCount{{Period: 1950, Songs: 2}, {Period: 2000, Songs: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := decades(tt.years)
+			if got == nil || !slices.Equal(got, tt.want) {
+				t.Errorf("decades() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
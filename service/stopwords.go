@@ -0,0 +1,29 @@
+package service
+
+// stopWords содержит служебные слова английского и русского языков,
+// которые не учитываются в списке самых частых слов
+var stopWords = makeWordSet(
+	// Английский
+	"a", "about", "after", "all", "am", "an", "and", "any", "are", "as", "at", "be", "been", "but", "by",
+	"can", "could", "did", "do", "does", "don't", "for", "from", "had", "has", "have", "he", "her", "here",
+	"him", "his", "how", "i", "i'm", "if", "in", "into", "is", "it", "it's", "its", "just", "me", "my", "no",
+	"not", "now", "of", "oh", "on", "one", "or", "our", "out", "so", "some", "such", "than", "that", "the",
+	"their", "them", "then", "there", "these", "they", "this", "those", "through", "to", "too", "up", "us",
+	"was", "we", "were", "what", "when", "where", "which", "while", "who", "why", "will", "with", "would",
+	"yet", "you", "you're", "your",
+	// Русский
+	"а", "без", "бы", "был", "была", "были", "было", "быть", "в", "вас", "во", "вот", "все", "всё", "вы",
+	"где", "да", "для", "до", "его", "ее", "её", "если", "есть", "еще", "ещё", "же", "за", "и", "из", "или",
+	"им", "их", "к", "как", "ко", "когда", "кто", "ли", "меня", "мне", "мы", "на", "над", "нас", "не", "нет",
+	"ни", "но", "о", "об", "он", "она", "они", "оно", "от", "по", "под", "при", "с", "со", "так", "там",
+	"те", "тебя", "тебе", "то", "тот", "ты", "у", "уж", "уже", "что", "чтоб", "чтобы", "эта", "эти", "это",
+	"этот", "я",
+)
+
+func makeWordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
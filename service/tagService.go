@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"song-libary/models"
+	"song-libary/repository"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ограничения длины названий совпадают с размером столбцов genres.name и tags.name
+const (
+	maxGenreNameLength = 100
+	maxTagNameLength   = 50
+)
+
+var (
+	ErrGenreNotFound    = NotFound("genre_not_found", "genre not found")
+	ErrDuplicateGenre   = Conflict("duplicate_genre", "genre already exists")
+	ErrInvalidGenre     = Invalid("invalid_genre", "genre name is required")
+	ErrGenreNameTooLong = Invalid("genre_name_too_long", fmt.Sprintf("genre name must be at most %d characters", maxGenreNameLength))
+	ErrTagNameTooLong   = Invalid("tag_name_too_long", fmt.Sprintf("tag name must be at most %d characters", maxTagNameLength))
+	ErrNothingToTag     = Invalid("nothing_to_tag", "no genres or tags provided")
+)
+
+type TagService struct {
+	Repo   repository.TagRepository
+	Logger *slog.Logger
+}
+
+func NewTagService(repo repository.TagRepository, logger *slog.Logger) *TagService {
+	return &TagService{Repo: repo, Logger: logger}
+}
+
+// AddGenre добавляет жанр в справочник
+func (s *TagService) AddGenre(ctx context.Context, req models.AddGenreRequest) (*models.Genre, error) {
+	s.Logger.InfoContext(ctx, "Adding genre", "name", req.Name, "parent", req.Parent)
+
+	name := normalizeName(req.Name)
+	if name == "" {
+		return nil, withField(ErrInvalidGenre, "name")
+	}
+	if err := checkNameLength([]string{name}, maxGenreNameLength, ErrGenreNameTooLong); err != nil {
+		return nil, withField(err, "name")
+	}
+
+	genre, err := s.Repo.CreateGenre(ctx, name, normalizeName(req.Parent))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrGenreNotFound):
+			return nil, ErrGenreNotFound
+		case errors.Is(err, repository.ErrDuplicateGenre):
+			return nil, ErrDuplicateGenre
+		}
+		s.Logger.ErrorContext(ctx, "Failed to add genre", "error", err)
+		return nil, err
+	}
+
+	s.Logger.InfoContext(ctx, "Genre added successfully", "name", name)
+	return genre, nil
+}
+
+// GetGenres возвращает справочник жанров
+func (s *TagService) GetGenres(ctx context.Context) ([]*models.Genre, error) {
+	s.Logger.InfoContext(ctx, "Fetching genres")
+	return s.Repo.GetGenres(ctx)
+}
+
+// TagSong привязывает к песне жанры и теги
+func (s *TagService) TagSong(ctx context.Context, req models.TagSongRequest) error {
+	s.Logger.InfoContext(ctx, "Tagging song", "group", req.Group, "song", req.Song)
+
+	genres, tags := normalizeNames(req.Genres), normalizeNames(req.Tags)
+	if len(genres) == 0 && len(tags) == 0 {
+		return ErrNothingToTag
+	}
+	if err := checkNameLength(genres, maxGenreNameLength, ErrGenreNameTooLong); err != nil {
+		return withField(err, "genres")
+	}
+	if err := checkNameLength(tags, maxTagNameLength, ErrTagNameTooLong); err != nil {
+		return withField(err, "tags")
+	}
+
+	if err := s.Repo.AddSongTags(ctx, req.Song, req.Group, genres, tags); err != nil {
+		return s.mapTagError(ctx, err)
+	}
+
+	s.Logger.InfoContext(ctx, "Song tagged successfully", "song", req.Song)
+	return nil
+}
+
+// UntagSong отвязывает от песни жанры и теги
+func (s *TagService) UntagSong(ctx context.Context, req models.TagSongRequest) error {
+	s.Logger.InfoContext(ctx, "Untagging song", "group", req.Group, "song", req.Song)
+
+	genres, tags := normalizeNames(req.Genres), normalizeNames(req.Tags)
+	if len(genres) == 0 && len(tags) == 0 {
+		return ErrNothingToTag
+	}
+
+	if err := s.Repo.RemoveSongTags(ctx, req.Song, req.Group, genres, tags); err != nil {
+		return s.mapTagError(ctx, err)
+	}
+
+	s.Logger.InfoContext(ctx, "Song untagged successfully", "song", req.Song)
+	return nil
+}
+
+// mapTagError переводит ошибки репозитория в ошибки сервиса
+func (s *TagService) mapTagError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrSongNotFound
+	case errors.Is(err, repository.ErrGenreNotFound):
+		return ErrGenreNotFound
+	default:
+		s.Logger.ErrorContext(ctx, "Failed to update song tags", "error", err)
+		return err
+	}
+}
+
+// checkNameLength возвращает errTooLong с первым названием длиннее limit символов
+func checkNameLength(names []string, limit int, errTooLong error) error {
+	for _, name := range names {
+		if utf8.RuneCountInString(name) > limit {
+			return fmt.Errorf("%w: %q", errTooLong, name)
+		}
+	}
+	return nil
+}
+
+// normalizeName приводит название жанра или тега к каноничному виду
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// normalizeNames нормализует список названий, убирая пустые значения и дубликаты
+func normalizeNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = normalizeName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
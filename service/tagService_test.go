@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"song-libary/models"
+	"song-libary/repository"
+	"strings"
+	"testing"
+)
+
+func TestTagServiceRejectsLongNames(t *testing.T) {
+	// Репозиторий не нужен: слишком длинные названия отклоняются до обращения к базе данных
+	s := NewTagService(nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		call  func() error
+		want  *Error
+		field string
+	}{
+		{
+			name: "genre",
+			call: func() error {
+				_, err := s.AddGenre(ctx, models.AddGenreRequest{Name: strings.Repeat("я", maxGenreNameLength+1)})
+				return err
+			},
+			want:  ErrGenreNameTooLong,
+			field: "name",
+		},
+		{
+			name: "song genre",
+			call: func() error {
+				return s.TagSong(ctx, models.TagSongRequest{Genres: []string{"rock", strings.Repeat("a", maxGenreNameLength+1)}})
+			},
+			want:  ErrGenreNameTooLong,
+			field: "genres",
+		},
+		{
+			name: "song tag",
+			call: func() error {
+				return s.TagSong(ctx, models.TagSongRequest{Tags: []string{strings.Repeat("b", maxTagNameLength+1)}})
+			},
+			want:  ErrTagNameTooLong,
+			field: "tags",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var e *Error
+			if !errors.As(err, &e) || e.Code != tt.want.Code {
+				t.Fatalf("error = %v, want %v", err, tt.want)
+			}
+			if len(e.Fields) != 1 || e.Fields[0].Field != tt.field {
+				t.Errorf("fields = %+v, want %q", e.Fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestNormalizeNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{name: "case and spaces", names: []string{"  Progressive   Rock ", "LIVE"}, want: []string{"progressive rock", "live"}},
+		{name: "duplicates after normalization", names: []string{"Ballad", "ballad", " BALLAD "}, want: []string{"ballad"}},
+		{name: "empty names dropped", names: []string{"", "   ", "acoustic"}, want: []string{"acoustic"}},
+		{name: "cyrillic", names: []string{"Русский Рок"}, want: []string{"русский рок"}},
+		{name: "nothing", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeNames(tt.names); got == nil || !slices.Equal(got, tt.want) {
+				t.Errorf("normalizeNames(%q) = %q, want %q", tt.names, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeTagRepo запоминает жанры и теги, переданные в AddSongTags
+type fakeTagRepo struct {
+	repository.TagRepository
+	genres, tags []string
+}
+
+func (r *fakeTagRepo) AddSongTags(_ context.Context, _, _ string, genres, tags []string) error {
+	r.genres, r.tags = genres, tags
+	return nil
+}
+
+func TestTagSongNormalizesNames(t *testing.T) {
+	repo := &fakeTagRepo{}
+	s := NewTagService(repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	err := s.TagSong(context.Background(), models.TagSongRequest{
+		Group:  "Muse",
+		Song:   "Hysteria",
+		Genres: []string{"Alternative  Rock"},
+		Tags:   []string{"Live", "live", " "},
+	})
+	if err != nil {
+		t.Fatalf("TagSong() error = %v", err)
+	}
+	if !slices.Equal(repo.genres, []string{"alternative rock"}) || !slices.Equal(repo.tags, []string{"live"}) {
+		t.Errorf("saved genres %q and tags %q, want [alternative rock] and [live]", repo.genres, repo.tags)
+	}
+
+	if err := s.TagSong(context.Background(), models.TagSongRequest{Tags: []string{"  "}}); !errors.Is(err, ErrNothingToTag) {
+		t.Errorf("TagSong() with blank tags error = %v, want ErrNothingToTag", err)
+	}
+}
+
+func TestNormalizeFilterTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    models.FilterParams
+		wantGenre string
+		wantTags  []string
+		wantMatch string
+		wantErr   *Error
+	}{
+		{
+			name:      "any by default",
+			params:    models.FilterParams{Genre: " Rock ", Tags: []string{"Live", "live", "Ballad"}},
+			wantGenre: "rock",
+			wantTags:  []string{"live", "ballad"},
+			wantMatch: models.TagsMatchAny,
+		},
+		{
+			name:      "all",
+			params:    models.FilterParams{Tags: []string{"live"}, TagsMatch: models.TagsMatchAll},
+			wantTags:  []string{"live"},
+			wantMatch: models.TagsMatchAll,
+		},
+		{name: "unknown match mode", params: models.FilterParams{Tags: []string{"live"}, TagsMatch: "some"}, wantErr: ErrInvalidTagsMatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeFilter(tt.params)
+			if tt.wantErr != nil {
+				var e *Error
+				if !errors.As(err, &e) || e.Code != tt.wantErr.Code || len(e.Fields) != 1 || e.Fields[0].Field != "tags_match" {
+					t.Fatalf("normalizeFilter() error = %v, want %v on tags_match", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeFilter() error = %v", err)
+			}
+			if got.Genre != tt.wantGenre || !slices.Equal(got.Tags, tt.wantTags) || got.TagsMatch != tt.wantMatch {
+				t.Errorf("genre, tags, match = %q, %q, %q, want %q, %q, %q",
+					got.Genre, got.Tags, got.TagsMatch, tt.wantGenre, tt.wantTags, tt.wantMatch)
+			}
+		})
+	}
+}
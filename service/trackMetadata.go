@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidISRC     = Invalid("invalid_isrc", "isrc must match the CC-XXX-YY-NNNNN format")
+	ErrDuplicateISRC   = Conflict("duplicate_isrc", "isrc already belongs to another song")
+	ErrInvalidBPM      = Invalid("invalid_bpm", "bpm must be between 1 and 999")
+	ErrInvalidDuration = Invalid("invalid_duration", "duration_ms must be positive")
+	ErrInvalidKey      = Invalid("invalid_key", "key must be a note with optional accidental and mode, e.g. C#m or Bb")
+	ErrInvalidRange    = Invalid("invalid_range", "range minimum must not exceed maximum")
+)
+
+// maxBPM ограничивает темп так, чтобы он помещался в SMALLINT и оставался правдоподобным
+const maxBPM = 999
+
+var (
+	// isrcPattern описывает ISRC без дефисов: страна, регистрант, год, номер записи
+	isrcPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{3}[0-9]{2}[0-9]{5}$`)
+	// keyPattern принимает записи вида C, C#, Db, C#m, A minor, Eb major
+	keyPattern = regexp.MustCompile(`^([A-Ga-g])\s*([#♯b♭]?)\s*(m|min|minor|maj|major)?$`)
+)
+
+// normalizeISRC приводит ISRC к каноничному виду (верхний регистр без дефисов) и проверяет формат
+func normalizeISRC(isrc string) (string, error) {
+	isrc = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(isrc), "-", ""))
+	if isrc == "" {
+		return "", nil
+	}
+	if !isrcPattern.MatchString(isrc) {
+		return "", ErrInvalidISRC
+	}
+	return isrc, nil
+}
+
+// normalizeKey приводит тональность к виду C, C#, Db или C#m
+func normalizeKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", nil
+	}
+
+	match := keyPattern.FindStringSubmatch(key)
+	if match == nil {
+		return "", ErrInvalidKey
+	}
+
+	normalized := strings.ToUpper(match[1])
+	switch match[2] {
+	case "#", "♯":
+		normalized += "#"
+	case "b", "♭":
+		normalized += "b"
+	}
+	if mode := strings.ToLower(match[3]); mode == "m" || mode == "min" || mode == "minor" {
+		normalized += "m"
+	}
+	return normalized, nil
+}
+
+// validateTrackMetadata проверяет числовые поля метаданных трека
+func validateTrackMetadata(durationMs, bpm *int) error {
+	if durationMs != nil && *durationMs <= 0 {
+		return ErrInvalidDuration
+	}
+	if bpm != nil && (*bpm <= 0 || *bpm > maxBPM) {
+		return ErrInvalidBPM
+	}
+	return nil
+}
+
+// metadataField возвращает имя поля запроса с префиксом prefix для ошибки validateTrackMetadata
+func metadataField(err error, prefix string) string {
+	if errors.Is(err, ErrInvalidBPM) {
+		return prefix + "bpm"
+	}
+	return prefix + "duration_ms"
+}
+
+// validateRange проверяет, что нижняя граница диапазона не превышает верхнюю
+func validateRange(min, max *int) error {
+	if min != nil && max != nil && *min > *max {
+		return ErrInvalidRange
+	}
+	return nil
+}
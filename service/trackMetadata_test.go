@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeISRC(t *testing.T) {
+	tests := []struct {
+		isrc string
+		want string
+		err  error
+	}{
+		{isrc: "", want: ""},
+		{isrc: "GBAHT0300099", want: "GBAHT0300099"},
+		{isrc: "GB-AHT-03-00099", want: "GBAHT0300099"},
+		{isrc: " gb-aht-03-00099 ", want: "GBAHT0300099"},
+		{isrc: "us-s1z-99-00001", want: "USS1Z9900001"},
+		{isrc: "12-AHT-03-00099", err: ErrInvalidISRC},
+		{isrc: "G-AHT-03-00099", err: ErrInvalidISRC},
+		{isrc: "GB-A!T-03-00099", err: ErrInvalidISRC},
+		{isrc: "GB-AH-03-00099", err: ErrInvalidISRC},
+		{isrc: "GB-AHT-3-00099", err: ErrInvalidISRC},
+		{isrc: "GB-AHT-03-0009X", err: ErrInvalidISRC},
+		{isrc: "GB-AHT-03-000999", err: ErrInvalidISRC},
+	}
+	for _, tt := range tests {
+		t.Run(tt.isrc, func(t *testing.T) {
+			got, err := normalizeISRC(tt.isrc)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("normalizeISRC(%q) error = %v, want %v", tt.isrc, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeISRC(%q) = %q, want %q", tt.isrc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+		err  error
+	}{
+		{key: "", want: ""},
+		{key: "C", want: "C"},
+		{key: "C#m", want: "C#m"},
+		{key: "c♯ minor", want: "C#m"},
+		{key: "Db major", want: "Db"},
+		{key: "D♭", want: "Db"},
+		{key: "a minor", want: "Am"},
+		{key: " bb min ", want: "Bbm"},
+		{key: "F maj", want: "F"},
+		{key: "H", err: ErrInvalidKey},
+		{key: "C##", err: ErrInvalidKey},
+		{key: "C dorian", err: ErrInvalidKey},
+		{key: "minor", err: ErrInvalidKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := normalizeKey(tt.key)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("normalizeKey(%q) error = %v, want %v", tt.key, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeKey(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTrackMetadata(t *testing.T) {
+	ptr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name       string
+		durationMs *int
+		bpm        *int
+		err        error
+		field      string
+	}{
+		{name: "not set"},
+		{name: "valid", durationMs: ptr(227000), bpm: ptr(94)},
+		{name: "bpm bounds", durationMs: ptr(1), bpm: ptr(maxBPM)},
+		{name: "zero duration", durationMs: ptr(0), err: ErrInvalidDuration, field: "duration_ms"},
+		{name: "negative duration", durationMs: ptr(-1), err: ErrInvalidDuration, field: "duration_ms"},
+		{name: "zero bpm", bpm: ptr(0), err: ErrInvalidBPM, field: "bpm"},
+		{name: "negative bpm", bpm: ptr(-120), err: ErrInvalidBPM, field: "bpm"},
+		{name: "bpm too large", bpm: ptr(maxBPM + 1), err: ErrInvalidBPM, field: "bpm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTrackMetadata(tt.durationMs, tt.bpm)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("validateTrackMetadata() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				if field := metadataField(err, ""); field != tt.field {
+					t.Errorf("metadataField() = %q, want %q", field, tt.field)
+				}
+				if field := metadataField(err, "new_"); field != "new_"+tt.field {
+					t.Errorf("metadataField() with prefix = %q, want %q", field, "new_"+tt.field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateRange(t *testing.T) {
+	ptr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name     string
+		min, max *int
+		err      error
+	}{
+		{name: "not set"},
+		{name: "only min", min: ptr(100)},
+		{name: "only max", max: ptr(100)},
+		{name: "equal", min: ptr(100), max: ptr(100)},
+		{name: "ordered", min: ptr(90), max: ptr(130)},
+		{name: "reversed", min: ptr(130), max: ptr(90), err: ErrInvalidRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateRange(tt.min, tt.max); !errors.Is(err, tt.err) {
+				t.Errorf("validateRange() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
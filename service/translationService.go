@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"regexp"
+	"song-libary/models"
+	"song-libary/profanity"
+	"song-libary/repository"
+	"strings"
+)
+
+var (
+	ErrTranslationNotFound      = NotFound("translation_not_found", "translation not found")
+	ErrDuplicateTranslation     = Conflict("duplicate_translation", "translation for this language already exists")
+	ErrInvalidLang              = Invalid("invalid_lang", "lang must be a language code such as en, ru or pt-BR")
+	ErrInvalidTranslation       = Invalid("invalid_translation", "translation text is required")
+	ErrInvalidTranslationStatus = Invalid("invalid_translation_status", "status must be one of \"draft\", \"review\" or \"published\"")
+)
+
+// langPattern принимает коды языков вида en, ru, pt-br
+var langPattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+type TranslationService struct {
+	Repo      repository.TranslationRepository
+	Songs     repository.SongRepository
+	Profanity *profanity.Filter
+	Logger    *slog.Logger
+}
+
+func NewTranslationService(repo repository.TranslationRepository, songs repository.SongRepository, logger *slog.Logger) *TranslationService {
+	return &TranslationService{Repo: repo, Songs: songs, Profanity: profanity.Default(), Logger: logger}
+}
+
+// AddTranslation добавляет перевод текста песни
+func (s *TranslationService) AddTranslation(ctx context.Context, req models.AddTranslationRequest) (*models.Translation, error) {
+	s.Logger.InfoContext(ctx, "Adding translation", "lang", req.Lang, "song", req.Song, "group", req.Group)
+
+	translation, err := newTranslation(req.Lang, req.Text, req.Translator, req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.SaveTranslation(ctx, req.Song, req.Group, translation); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrSongNotFound
+		case errors.Is(err, repository.ErrDuplicateTranslation):
+			return nil, ErrDuplicateTranslation
+		}
+		s.Logger.ErrorContext(ctx, "Failed to add translation", "error", err)
+		return nil, err
+	}
+
+	s.Logger.InfoContext(ctx, "Translation added successfully", "lang", translation.Lang)
+	return translation, nil
+}
+
+// UpdateTranslation изменяет существующий перевод
+func (s *TranslationService) UpdateTranslation(ctx context.Context, req models.UpdateTranslationRequest) (*models.Translation, error) {
+	s.Logger.InfoContext(ctx, "Updating translation", "lang", req.Lang, "song", req.Song, "group", req.Group)
+
+	translation, err := newTranslation(req.Lang, req.NewText, req.NewTranslator, req.NewStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.UpdateTranslation(ctx, req.Song, req.Group, translation); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTranslationNotFound
+		}
+		s.Logger.ErrorContext(ctx, "Failed to update translation", "error", err)
+		return nil, err
+	}
+
+	s.Logger.InfoContext(ctx, "Translation updated successfully", "lang", translation.Lang)
+	return translation, nil
+}
+
+// DeleteTranslation удаляет перевод песни
+func (s *TranslationService) DeleteTranslation(ctx context.Context, songName, group, lang string) error {
+	s.Logger.InfoContext(ctx, "Deleting translation", "lang", lang, "song", songName, "group", group)
+
+	if err := s.Repo.DeleteTranslation(ctx, songName, group, normalizeLang(lang)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTranslationNotFound
+		}
+		s.Logger.ErrorContext(ctx, "Failed to delete translation", "error", err)
+		return err
+	}
+	return nil
+}
+
+// GetTranslations возвращает все переводы песни
+func (s *TranslationService) GetTranslations(ctx context.Context, songName, group string) ([]*models.Translation, error) {
+	s.Logger.InfoContext(ctx, "Fetching translations", "song", songName, "group", group)
+
+	translations, err := s.Repo.GetTranslations(ctx, songName, group)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSongNotFound
+		}
+		s.Logger.ErrorContext(ctx, "Failed to fetch translations", "error", err)
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetTranslatedText возвращает перевод песни с той же разбивкой на куплеты и пагинацией, что и GetSongText.
+// В режиме Parallel куплеты оригинала и перевода дополнительно возвращаются парами
+func (s *TranslationService) GetTranslatedText(ctx context.Context, songName, group string, params models.SongTextParams) (models.SongTextResponse, error) {
+	s.Logger.InfoContext(ctx, "Fetching translated text", "lang", params.Lang, "song", songName, "limit", params.Limit, "offset", params.Offset, "parallel", params.Parallel, "clean", params.Clean)
+
+	lang := normalizeLang(params.Lang)
+	translation, err := s.Repo.GetTranslation(ctx, songName, group, lang)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.SongTextResponse{}, ErrTranslationNotFound
+		}
+		s.Logger.ErrorContext(ctx, "Failed to fetch translation", "error", err)
+		return models.SongTextResponse{}, err
+	}
+
+	translated := splitVerses(translation.Text)
+	total := len(translated)
+
+	var original []string
+	if params.Parallel {
+		text, err := s.Songs.GetSongTextByNameAndGroup(ctx, songName, group)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return models.SongTextResponse{}, ErrSongNotFound
+			}
+			s.Logger.ErrorContext(ctx, "Failed to fetch song text", "error", err)
+			return models.SongTextResponse{}, err
+		}
+		original = splitVerses(text)
+		total = max(total, len(original))
+	}
+
+	start, end := pageBounds(total, params.Limit, params.Offset)
+	response := models.SongTextResponse{
+		SongName: songName,
+		Group:    group,
+		Lang:     lang,
+		Verses:   make([]string, 0, end-start),
+		Limit:    params.Limit,
+		Offset:   params.Offset,
+		Total:    total,
+	}
+	for i := start; i < end; i++ {
+		if params.Rhymes {
+			response.Rhymes = append(response.Rhymes, rhymeScheme(verseAt(translated, i)))
+		}
+		verse := s.cleanVerseAt(translated, i, params.Clean)
+		response.Verses = append(response.Verses, verse)
+		if params.Parallel {
+			response.Pairs = append(response.Pairs, models.VersePair{
+				Original:    s.cleanVerseAt(original, i, params.Clean),
+				Translation: verse,
+			})
+		}
+	}
+
+	s.Logger.InfoContext(ctx, "Returning verses", "count", len(response.Verses), "lang", lang, "song", songName)
+	return response, nil
+}
+
+// newTranslation проверяет поля перевода и заполняет значения по умолчанию
+func newTranslation(lang, text, translator, status string) (*models.Translation, error) {
+	lang = normalizeLang(lang)
+	if !langPattern.MatchString(lang) {
+		return nil, withField(ErrInvalidLang, "lang")
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, withField(ErrInvalidTranslation, "text")
+	}
+
+	switch status {
+	case "":
+		status = models.TranslationStatusDraft
+	case models.TranslationStatusDraft, models.TranslationStatusReview, models.TranslationStatusPublished:
+	default:
+		return nil, withField(ErrInvalidTranslationStatus, "status")
+	}
+
+	return &models.Translation{
+		Lang:       lang,
+		Text:       text,
+		Translator: strings.TrimSpace(translator),
+		Status:     status,
+	}, nil
+}
+
+// normalizeLang приводит код языка к нижнему регистру
+func normalizeLang(lang string) string {
+	return strings.ToLower(strings.TrimSpace(lang))
+}
+
+// verseAt возвращает куплет по индексу или пустую строку, если куплета нет
+func verseAt(verses []string, i int) string {
+	if i < len(verses) {
+		return verses[i]
+	}
+	return ""
+}
+
+// cleanVerseAt возвращает куплет по индексу; при clean ненормативная лексика в нём маскируется
+func (s *TranslationService) cleanVerseAt(verses []string, i int, clean bool) string {
+	verse := verseAt(verses, i)
+	if clean {
+		return s.Profanity.Mask(verse)
+	}
+	return verse
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"song-libary/models"
+	"song-libary/repository"
+	"testing"
+)
+
+type fakeTranslationRepo struct {
+	repository.TranslationRepository
+	translation *models.Translation
+}
+
+func (r *fakeTranslationRepo) GetTranslation(context.Context, string, string, string) (*models.Translation, error) {
+	return r.translation, nil
+}
+
+type fakeSongTextRepo struct {
+	repository.SongRepository
+	text string
+	err  error
+}
+
+func (r *fakeSongTextRepo) GetSongTextByNameAndGroup(context.Context, string, string) (string, error) {
+	return r.text, r.err
+}
+
+func TestGetTranslatedTextParallelErrors(t *testing.T) {
+	translations := &fakeTranslationRepo{translation: &models.Translation{Lang: "ru", Text: "Куплет"}}
+	dbErr := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		songErr error
+		want    error
+	}{
+		{name: "song found"},
+		{name: "song missing", songErr: sql.ErrNoRows, want: ErrSongNotFound},
+		{name: "database failure passed through", songErr: dbErr, want: dbErr},
+		{name: "deadline passed through", songErr: context.DeadlineExceeded, want: context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs := &fakeSongTextRepo{text: "Verse", err: tt.songErr}
+			s := NewTranslationService(translations, songs, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			response, err := s.GetTranslatedText(context.Background(), "Song", "Group",
+				models.SongTextParams{Lang: "ru", Parallel: true, Limit: 3})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("GetTranslatedText() error = %v, want %v", err, tt.want)
+			}
+			if tt.want == nil && (len(response.Pairs) != 1 || response.Pairs[0].Original != "Verse") {
+				t.Errorf("pairs = %+v, want original verse", response.Pairs)
+			}
+		})
+	}
+}
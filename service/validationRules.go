@@ -0,0 +1,26 @@
+package service
+
+import "song-libary/validation"
+
+// Правила проверки запросов, которые опираются на форматы сервиса: обработчики проверяют
+// поля декларативно до вызова сервиса, а проверки в AddSong и UpdateSong остаются страховкой
+func init() {
+	validation.Register("isrc", ErrInvalidISRC.Code, func(value string) error {
+		_, err := normalizeISRC(value)
+		return err
+	})
+	validation.Register("key", ErrInvalidKey.Code, func(value string) error {
+		_, err := normalizeKey(value)
+		return err
+	})
+	validation.Register("link", ErrInvalidLink.Code, func(value string) error {
+		_, err := detectSongLink(value)
+		return err
+	})
+	validation.Register("lang", ErrInvalidLang.Code, func(value string) error {
+		if !langPattern.MatchString(normalizeLang(value)) {
+			return ErrInvalidLang
+		}
+		return nil
+	})
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"slices"
+	"song-libary/models"
+	"song-libary/validation"
+	"testing"
+)
+
+func TestSongRequestRules(t *testing.T) {
+	ptr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		request any
+		want    []string // Поля с ошибками в порядке ответа
+	}{
+		{
+			name: "valid metadata",
+			request: models.AddSongRequest{Group: "Muse", Song: "Hysteria", Link: "https://youtu.be/3dm_5qWWDV8",
+				DurationMs: ptr(227000), ISRC: "GB-AHT-03-00099", BPM: ptr(94), Key: "A minor", Language: "EN"},
+		},
+		{
+			name:    "spotify uri",
+			request: models.AddSongRequest{Group: "Muse", Song: "Hysteria", Link: "spotify:track:7xyYsOvq5Ec3P4fr6mM9fD"},
+		},
+		{
+			name:    "malformed spotify uri",
+			request: models.AddSongRequest{Group: "Muse", Song: "Hysteria", Link: "spotify:track:short"},
+			want:    []string{"link"},
+		},
+		{
+			name: "all violations at once",
+			request: models.AddSongRequest{Group: "Muse", Song: "Hysteria", Link: "ftp://example.com",
+				DurationMs: ptr(0), ISRC: "GB-AHT", BPM: ptr(1000), Key: "H", Language: "english!"},
+			want: []string{"link", "duration_ms", "isrc", "bpm", "key", "language"},
+		},
+		{
+			name: "update request",
+			request: models.UpdateSongRequest{OldSongName: "Hysteria", OldGroup: "Muse", NewGroup: "Muse", NewSongName: "Hysteria",
+				NewLink: "spotify:track:7xyYsOvq5Ec3P4fr6mM9fD", NewISRC: "bad", NewBPM: ptr(-1)},
+			want: []string{"new_isrc", "new_bpm"},
+		},
+		{
+			name:    "filter key",
+			request: models.FilterParams{Key: "X#", Limit: 10},
+			want:    []string{"key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, field := range validation.Struct(tt.request) {
+				got = append(got, field.Field)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("error fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
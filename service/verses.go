@@ -0,0 +1,38 @@
+package service
+
+import "strings"
+
+// verseSeparator разделяет куплеты в сохранённом тексте песни
+const verseSeparator = "\\n\\n"
+
+// splitVerses разбивает текст песни на куплеты
+func splitVerses(text string) []string {
+	return strings.Split(text, verseSeparator)
+}
+
+// pageBounds возвращает границы страницы [start, end) для списка длины total
+func pageBounds(total, limit, offset int) (int, int) {
+	start := offset
+	if start >= total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// lineSeparator разделяет строки внутри куплета
+const lineSeparator = "\\n"
+
+// splitLines разбивает куплет на непустые строки
+func splitLines(verse string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(verse, "\n", lineSeparator), lineSeparator) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// httpServer — сервер, который умеет дождаться текущих запросов (Shutdown) или оборвать их (Close)
+type httpServer interface {
+	Shutdown(ctx context.Context) error
+	Close() error
+}
+
+// readiness снимает готовность сервиса: /readyz начинает отвечать 503
+type readiness interface {
+	SetShuttingDown()
+}
+
+// shutdown останавливает сервис по порядку: сначала снимает готовность и ещё delay обслуживает запросы,
+// затем перестаёт принимать запросы и дожидается текущих, затем останавливает фоновые задачи и закрывает пул соединений с базой данных.
+// Всё, что не успело завершиться до дедлайна ctx, прерывается
+func shutdown(ctx context.Context, logger *slog.Logger, delay time.Duration, server httpServer, health readiness, stopWorkers context.CancelFunc, workers *sync.WaitGroup, db io.Closer) {
+	health.SetShuttingDown()
+
+	if delay > 0 {
+		// Балансировщик узнаёт о неготовности только со следующей пробой /readyz;
+		// до этого новые запросы ещё приходят и должны быть обслужены
+		logger.Info("Waiting for load balancer to stop routing traffic", "delay", delay)
+		time.Sleep(delay)
+	}
+
+	logger.Info("Draining HTTP connections")
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error("HTTP server did not drain in time", "error", err)
+		server.Close()
+	}
+
+	logger.Info("Stopping background workers")
+	stopWorkers()
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.Error("Background workers did not stop in time", "error", ctx.Err())
+	}
+
+	db.Close()
+	logger.Info("Server stopped")
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"song-libary/models"
+	"song-libary/service"
+	"sync"
+	"testing"
+	"time"
+)
+
+// shutdownLog записывает шаги остановки в порядке выполнения
+type shutdownLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *shutdownLog) add(event string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *shutdownLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.events)
+}
+
+// fakeServer записывает готовность сервиса в момент начала Shutdown; drainErr имитирует
+// запросы, не завершившиеся до дедлайна
+type fakeServer struct {
+	log       *shutdownLog
+	health    *service.HealthService
+	drainErr  error
+	drainedAt time.Time
+}
+
+func (s *fakeServer) Shutdown(ctx context.Context) error {
+	s.drainedAt = time.Now()
+	s.log.add("drain, ready: " + s.health.Readiness(ctx).Status)
+	return s.drainErr
+}
+
+func (s *fakeServer) Close() error {
+	s.log.add("close connections")
+	return nil
+}
+
+type fakeDB struct{ log *shutdownLog }
+
+func (d fakeDB) Close() error {
+	d.log.add("close db")
+	return nil
+}
+
+func TestShutdown(t *testing.T) {
+	tests := []struct {
+		name        string
+		delay       time.Duration
+		drainErr    error
+		stuckWorker bool // Фоновая задача не реагирует на отмену
+		want        []string
+	}{
+		{
+			name:  "graceful",
+			delay: 20 * time.Millisecond,
+			want:  []string{"drain, ready: " + models.HealthStatusFail, "worker stopped", "close db"},
+		},
+		{
+			name:     "connections not drained in time",
+			drainErr: context.DeadlineExceeded,
+			want:     []string{"drain, ready: " + models.HealthStatusFail, "close connections", "worker stopped", "close db"},
+		},
+		{
+			name:        "worker not stopped in time",
+			stuckWorker: true,
+			want:        []string{"drain, ready: " + models.HealthStatusFail, "close db"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := &shutdownLog{}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			health := service.NewHealthService(logger)
+
+			workersCtx, stopWorkers := context.WithCancel(context.Background())
+			release := make(chan struct{})
+			defer close(release)
+			var workers sync.WaitGroup
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				if tt.stuckWorker {
+					<-release
+					return
+				}
+				<-workersCtx.Done()
+				log.add("worker stopped")
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.delay+100*time.Millisecond)
+			defer cancel()
+			server := &fakeServer{log: log, health: health, drainErr: tt.drainErr}
+			started := time.Now()
+			shutdown(ctx, logger, tt.delay, server, health, stopWorkers, &workers, fakeDB{log: log})
+
+			if got := log.get(); !slices.Equal(got, tt.want) {
+				t.Errorf("shutdown steps = %q, want %q", got, tt.want)
+			}
+			// До истечения delay сервер продолжает принимать запросы
+			if waited := server.drainedAt.Sub(started); waited < tt.delay {
+				t.Errorf("draining started after %v, want at least the delay %v", waited, tt.delay)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitizeStatement(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "string literal", query: "SELECT id FROM songs WHERE group_name = 'Muse'", want: "SELECT id FROM songs WHERE group_name = ?"},
+		{name: "escaped quote", query: "SELECT id FROM songs WHERE song_name = 'Don''t Stop Me Now' AND id = $1", want: "SELECT id FROM songs WHERE song_name = ? AND id = $1"},
+		{name: "digits inside string", query: "SELECT '2006-07-16' AS d", want: "SELECT ? AS d"},
+		{name: "numbers next to placeholders", query: "SELECT * FROM songs WHERE bpm >= $1 AND bpm <= 120 LIMIT $12 OFFSET 10", want: "SELECT * FROM songs WHERE bpm >= $1 AND bpm <= ? LIMIT $12 OFFSET ?"},
+		{name: "placeholder cast", query: "SELECT $1::bigint[], 2.5, -3", want: "SELECT $1::bigint[], ?, -?"},
+		{name: "digits in identifiers", query: "SELECT col1, t2.x FROM t2", want: "SELECT col1, t2.x FROM t2"},
+		{name: "whitespace collapsed", query: "\n\t\tSELECT id\n\t\tFROM   songs\n\t\tWHERE id = $1\n\t", want: "SELECT id FROM songs WHERE id = $1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeStatement(tt.query); got != tt.want {
+				t.Errorf("SanitizeStatement(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeStatementTruncatesOnRuneBoundary(t *testing.T) {
+	// Двухбайтовая «я» начинается на нечётном байте, поэтому граница обрезки попадает внутрь руны
+	query := "SELECT " + strings.Repeat("я", maxStatementLen)
+	got := SanitizeStatement(query)
+	if len(got) > maxStatementLen {
+		t.Errorf("len = %d, want at most %d", len(got), maxStatementLen)
+	}
+	if !utf8.ValidString(got) {
+		t.Error("truncated statement is not valid UTF-8")
+	}
+	if len(got) < maxStatementLen-utf8.UTFMax {
+		t.Errorf("len = %d, truncated more than one rune", len(got))
+	}
+}
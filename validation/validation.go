@@ -0,0 +1,164 @@
+// Package validation проверяет запросы по правилам, объявленным в теге validate полей структуры,
+// например `validate:"required,max=100"`. Проверка возвращает все нарушения сразу; поле в ошибке
+// называется так же, как в JSON.
+//
+// Правила:
+//   - required — строка не пустая (без учёта пробелов);
+//   - max=N, min=N — длина строки в символах или значение числа;
+//   - url — абсолютная http(s)-ссылка;
+//   - date — дата в формате YYYY-MM-DD или DD.MM.YYYY.
+//
+// Пакеты, которые знают формат значений предметной области (ISRC, тональность, ссылка на трек),
+// добавляют строковые правила через Register.
+//
+// Кроме required, правила не применяются к пустым строкам и nil-указателям.
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"song-libary/models"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// dateLayouts — форматы даты релиза, которые встречаются в библиотеке
+var dateLayouts = []string{time.DateOnly, "02.01.2006"}
+
+// customRule — строковое правило, добавленное через Register
+type customRule struct {
+	code  string
+	check func(value string) error
+}
+
+// rules — правила, добавленные через Register
+var customRules = map[string]customRule{}
+
+// Register добавляет строковое правило name: check возвращает ошибку для некорректного непустого
+// значения, её текст становится сообщением нарушения с кодом code. Правила регистрируются
+// при инициализации пакетов, до первой проверки
+func Register(name, code string, check func(value string) error) {
+	if _, ok := customRules[name]; ok {
+		panic(fmt.Sprintf("validation: rule %q is already registered", name))
+	}
+	customRules[name] = customRule{code: code, check: check}
+}
+
+// Struct проверяет поля структуры v (или указателя на неё) и возвращает все нарушения
+func Struct(v any) []models.FieldError {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validation: expected a struct, got %T", v))
+	}
+
+	var errs []models.FieldError
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		tag, ok := field.Tag.Lookup("validate")
+		if !ok {
+			continue
+		}
+		name := fieldName(field)
+		for _, rule := range strings.Split(tag, ",") {
+			if err, failed := check(rule, value.Field(i)); failed {
+				errs = append(errs, models.FieldError{Field: name, Code: err.code, Message: err.message})
+				break // Об одном поле достаточно одного нарушения
+			}
+		}
+	}
+	return errs
+}
+
+// violation — нарушенное правило
+type violation struct {
+	code    string
+	message string
+}
+
+// check применяет правило rule к значению поля
+func check(rule string, field reflect.Value) (violation, bool) {
+	rule, arg, _ := strings.Cut(rule, "=")
+
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return violation{}, false
+		}
+		field = field.Elem()
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return checkString(rule, arg, field.String())
+	case reflect.Int, reflect.Int64:
+		return checkInt(rule, arg, field.Int())
+	}
+	panic(fmt.Sprintf("validation: rule %q is not supported for %s", rule, field.Kind()))
+}
+
+func checkString(rule, arg, value string) (violation, bool) {
+	if rule == "required" {
+		return violation{"required", "is required"}, strings.TrimSpace(value) == ""
+	}
+	if value == "" {
+		return violation{}, false
+	}
+
+	switch rule {
+	case "max":
+		limit := number(arg)
+		return violation{"too_long", fmt.Sprintf("must be at most %d characters", limit)}, int64(utf8.RuneCountInString(value)) > limit
+	case "min":
+		limit := number(arg)
+		return violation{"too_short", fmt.Sprintf("must be at least %d characters", limit)}, int64(utf8.RuneCountInString(value)) < limit
+	case "url":
+		parsed, err := url.Parse(value)
+		invalid := err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == ""
+		return violation{"invalid_url", "must be an absolute http(s) URL"}, invalid
+	case "date":
+		for _, layout := range dateLayouts {
+			if _, err := time.Parse(layout, value); err == nil {
+				return violation{}, false
+			}
+		}
+		return violation{"invalid_date", "must be a date in YYYY-MM-DD or DD.MM.YYYY format"}, true
+	}
+	if custom, ok := customRules[rule]; ok {
+		if err := custom.check(value); err != nil {
+			return violation{custom.code, err.Error()}, true
+		}
+		return violation{}, false
+	}
+	panic(fmt.Sprintf("validation: unknown rule %q for string", rule))
+}
+
+func checkInt(rule, arg string, value int64) (violation, bool) {
+	switch rule {
+	case "max":
+		limit := number(arg)
+		return violation{"too_large", fmt.Sprintf("must be at most %d", limit)}, value > limit
+	case "min":
+		limit := number(arg)
+		return violation{"too_small", fmt.Sprintf("must be at least %d", limit)}, value < limit
+	}
+	panic(fmt.Sprintf("validation: unknown rule %q for int", rule))
+}
+
+// number разбирает аргумент правила; ошибка в теге — ошибка программиста
+func number(arg string) int64 {
+	n, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validation: invalid rule argument %q", arg))
+	}
+	return n
+}
+
+// fieldName возвращает имя поля в JSON
+func fieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
@@ -0,0 +1,166 @@
+package validation
+
+import (
+	"errors"
+	"slices"
+	"song-libary/models"
+	"strings"
+	"testing"
+)
+
+type request struct {
+	Name    string  `json:"name" validate:"required,max=5"`
+	Code    string  `json:"code,omitempty" validate:"min=2"`
+	Link    string  `json:"link" validate:"url"`
+	Date    string  `json:"date" validate:"date"`
+	Count   int     `json:"count" validate:"min=1,max=10"`
+	Limit   *int    `json:"limit" validate:"max=3"`
+	Comment string  `json:"-" validate:"max=3"`
+	Ignored string  `json:"ignored"`
+	Note    *string `validate:"max=2"`
+}
+
+func TestStruct(t *testing.T) {
+	valid := request{Name: "Queen", Count: 1}
+	ptr := func(v int) *int { return &v }
+	str := func(v string) *string { return &v }
+
+	tests := []struct {
+		name   string
+		modify func(*request)
+		want   []models.FieldError
+	}{
+		{name: "valid", modify: func(*request) {}},
+		{
+			name:   "required",
+			modify: func(r *request) { r.Name = "   " },
+			want:   []models.FieldError{{Field: "name", Code: "required", Message: "is required"}},
+		},
+		{
+			name:   "too long counts characters",
+			modify: func(r *request) { r.Name = "Ария!!" },
+			want:   []models.FieldError{{Field: "name", Code: "too_long", Message: "must be at most 5 characters"}},
+		},
+		{name: "multibyte within limit", modify: func(r *request) { r.Name = "Ария" }},
+		{
+			name:   "too short",
+			modify: func(r *request) { r.Code = "x" },
+			want:   []models.FieldError{{Field: "code", Code: "too_short", Message: "must be at least 2 characters"}},
+		},
+		{
+			name:   "url",
+			modify: func(r *request) { r.Link = "ftp://example.com" },
+			want:   []models.FieldError{{Field: "link", Code: "invalid_url", Message: "must be an absolute http(s) URL"}},
+		},
+		{
+			name:   "relative url",
+			modify: func(r *request) { r.Link = "/songs" },
+			want:   []models.FieldError{{Field: "link", Code: "invalid_url", Message: "must be an absolute http(s) URL"}},
+		},
+		{name: "iso date", modify: func(r *request) { r.Date = "1975-10-31" }},
+		{name: "dotted date", modify: func(r *request) { r.Date = "31.10.1975" }},
+		{
+			name:   "invalid date",
+			modify: func(r *request) { r.Date = "1975-13-31" },
+			want:   []models.FieldError{{Field: "date", Code: "invalid_date", Message: "must be a date in YYYY-MM-DD or DD.MM.YYYY format"}},
+		},
+		{
+			name:   "int bounds",
+			modify: func(r *request) { r.Count = 0 },
+			want:   []models.FieldError{{Field: "count", Code: "too_small", Message: "must be at least 1"}},
+		},
+		{
+			name:   "pointer checked when set",
+			modify: func(r *request) { r.Limit = ptr(4) },
+			want:   []models.FieldError{{Field: "limit", Code: "too_large", Message: "must be at most 3"}},
+		},
+		{
+			name:   "field without json name",
+			modify: func(r *request) { r.Comment = "long"; r.Note = str("abc") },
+			want: []models.FieldError{
+				{Field: "Comment", Code: "too_long", Message: "must be at most 3 characters"},
+				{Field: "Note", Code: "too_long", Message: "must be at most 2 characters"},
+			},
+		},
+		{
+			name:   "all violations in field order",
+			modify: func(r *request) { r.Name = ""; r.Link = "nope"; r.Count = 11 },
+			want: []models.FieldError{
+				{Field: "name", Code: "required", Message: "is required"},
+				{Field: "link", Code: "invalid_url", Message: "must be an absolute http(s) URL"},
+				{Field: "count", Code: "too_large", Message: "must be at most 10"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid
+			tt.modify(&r)
+			if got := Struct(&r); !slices.Equal(got, tt.want) {
+				t.Errorf("Struct() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("upper", "not_upper", func(value string) error {
+		if strings.ToUpper(value) != value {
+			return errors.New("must be upper case")
+		}
+		return nil
+	})
+
+	type code struct {
+		Value string `json:"value" validate:"max=3,upper"`
+	}
+	tests := []struct {
+		value string
+		want  []models.FieldError
+	}{
+		{value: ""},
+		{value: "ABC"},
+		{value: "abc", want: []models.FieldError{{Field: "value", Code: "not_upper", Message: "must be upper case"}}},
+		{value: "abcd", want: []models.FieldError{{Field: "value", Code: "too_long", Message: "must be at most 3 characters"}}},
+	}
+	for _, tt := range tests {
+		if got := Struct(code{Value: tt.value}); !slices.Equal(got, tt.want) {
+			t.Errorf("Struct(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register() of a duplicate rule did not panic")
+		}
+	}()
+	Register("upper", "not_upper", func(string) error { return nil })
+}
+
+func TestStructPanicsOnProgrammerErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{name: "not a struct", v: 42},
+		{name: "unknown rule", v: struct {
+			Name string `validate:"email"`
+		}{Name: "x"}},
+		{name: "invalid argument", v: struct {
+			Name string `validate:"max=many"`
+		}{Name: "x"}},
+		{name: "unsupported kind", v: struct {
+			Ratio float64 `validate:"max=1"`
+		}{Ratio: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Struct() did not panic")
+				}
+			}()
+			Struct(tt.v)
+		})
+	}
+}